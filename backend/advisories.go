@@ -0,0 +1,389 @@
+// =========================================================================
+// advisories.go
+// Created: October 19, 2026
+//
+// This file takes care of importing security advisories from a local
+// OSV-format advisory dump (see ossf.github.io/osv-schema). Advisories are
+// only ever loaded by an administrator, never fetched at request time
+// =========================================================================
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ADVISORY_BATCH = 500 // number of advisories stored per insert
+
+	// advisory severity levels
+	SEVERITY_CRITICAL = "CRITICAL"
+	SEVERITY_HIGH     = "HIGH"
+	SEVERITY_MODERATE = "MODERATE"
+	SEVERITY_LOW      = "LOW"
+	SEVERITY_UNKNOWN  = "UNKNOWN"
+
+	// OSV range types which can be compared (GIT ranges are commit hashes)
+	RANGE_SEMVER    = "SEMVER"
+	RANGE_ECOSYSTEM = "ECOSYSTEM"
+)
+
+// severity levels from most to least severe
+var severityRanks = map[string]int{
+	SEVERITY_CRITICAL: 0, SEVERITY_HIGH: 1, SEVERITY_MODERATE: 2, SEVERITY_LOW: 3, SEVERITY_UNKNOWN: 4,
+}
+
+// Single advisory of an OSV dump. Only the fields used for matching are kept.
+type OsvAdvisory struct {
+	ID        string     `json:"id"`
+	Summary   string     `json:"summary"`
+	Details   string     `json:"details"`
+	Aliases   []string   `json:"aliases"`
+	Modified  time.Time  `json:"modified"`
+	Withdrawn *time.Time `json:"withdrawn"`
+	Severity  []struct {
+		Type  string `json:"type"`
+		Score string `json:"score"`
+	} `json:"severity"`
+	Affected []struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		Ranges []struct {
+			Type   string              `json:"type"`
+			Events []map[string]string `json:"events"`
+		} `json:"ranges"`
+		Versions         []string               `json:"versions"`
+		DatabaseSpecific map[string]interface{} `json:"database_specific"`
+	} `json:"affected"`
+	DatabaseSpecific map[string]interface{} `json:"database_specific"`
+}
+
+// Imports an OSV advisory dump, replacing any advisory with the same ID, then
+// re-evaluates the vulnerabilities of every submission against the updated
+// advisories. Advisories for ecosystems whose manifests are not parsed are skipped.
+//
+// Params:
+// 	db (*gorm.DB) : the db to import the advisories into
+// 	path (string) : an OSV dump as a zip archive, a directory or a single JSON file
+// Returns:
+// 	(int) : the number of advisories imported
+// 	(error) : an error if the dump cannot be read or stored
+func importAdvisories(db *gorm.DB, path string) (int, error) {
+	osvAdvisories, err := readOsvDump(path)
+	if err != nil {
+		return 0, err
+	}
+	advisories := []Advisory{}
+	withdrawn := []string{}
+	for _, osvAdvisory := range osvAdvisories {
+		if osvAdvisory.Withdrawn != nil {
+			withdrawn = append(withdrawn, osvAdvisory.ID)
+		} else if advisory := convertOsvAdvisory(osvAdvisory); len(advisory.Affected) > 0 {
+			advisories = append(advisories, advisory)
+		}
+	}
+
+	if err := db.Transaction(func(tx *gorm.DB) error {
+		// replaces previously imported versions of the advisories
+		ids := withdrawn
+		for _, advisory := range advisories {
+			ids = append(ids, advisory.ID)
+		}
+		for start := 0; start < len(ids); start += ADVISORY_BATCH {
+			batch := ids[start:int(math.Min(float64(start+ADVISORY_BATCH), float64(len(ids))))]
+			if err := tx.Where("advisory_id IN ?", batch).Delete(&AffectedPackage{}).Error; err != nil {
+				return err
+			} else if err := tx.Where("id IN ?", batch).Delete(&Advisory{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(advisories) > 0 {
+			if err := tx.Omit(clause.Associations).CreateInBatches(&advisories, ADVISORY_BATCH).Error; err != nil {
+				return err
+			}
+			affected := []AffectedPackage{}
+			for _, advisory := range advisories {
+				affected = append(affected, advisory.Affected...)
+			}
+			if err := tx.CreateInBatches(&affected, ADVISORY_BATCH).Error; err != nil {
+				return err
+			}
+		}
+		return reevaluateVulnerabilities(tx)
+	}); err != nil {
+		return 0, err
+	}
+	log.Printf("[INFO] Imported %d advisories (%d withdrawn) from %s", len(advisories), len(withdrawn), path)
+	return len(advisories), nil
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Reads the advisories of an OSV dump. OSV publishes its dumps as zip
+// archives of JSON files, one per advisory.
+func readOsvDump(path string) ([]OsvAdvisory, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	advisories := []OsvAdvisory{}
+	add := func(name string, data []byte) error {
+		if !strings.EqualFold(filepath.Ext(name), ".json") {
+			return nil
+		}
+		fileAdvisories, err := decodeOsvFile(data)
+		if err != nil {
+			return &BadAdvisoryError{Path: name, Err: err}
+		}
+		advisories = append(advisories, fileAdvisories...)
+		return nil
+	}
+
+	switch {
+	case info.IsDir():
+		err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(filePath)
+			if err != nil {
+				return err
+			}
+			return add(filePath, data)
+		})
+	case strings.EqualFold(filepath.Ext(path), ".zip"):
+		var archive *zip.ReadCloser
+		if archive, err = zip.OpenReader(path); err != nil {
+			return nil, err
+		}
+		defer archive.Close()
+		for _, file := range archive.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+			reader, err := file.Open()
+			if err != nil {
+				return nil, err
+			}
+			data, err := io.ReadAll(reader)
+			reader.Close()
+			if err != nil {
+				return nil, err
+			} else if err := add(file.Name, data); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		var data []byte
+		if data, err = os.ReadFile(path); err == nil {
+			err = add(path, data)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return advisories, nil
+}
+
+// decodes a JSON file holding either a single advisory or a list of advisories
+func decodeOsvFile(data []byte) ([]OsvAdvisory, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		advisories := []OsvAdvisory{}
+		err := json.Unmarshal(data, &advisories)
+		return advisories, err
+	}
+	advisory := OsvAdvisory{}
+	err := json.Unmarshal(data, &advisory)
+	return []OsvAdvisory{advisory}, err
+}
+
+// Converts an OSV advisory to the stored advisory, with one affected package
+// row per version interval of its comparable ranges.
+func convertOsvAdvisory(osvAdvisory OsvAdvisory) Advisory {
+	advisory := Advisory{
+		ID:       osvAdvisory.ID,
+		Summary:  osvAdvisory.Summary,
+		Aliases:  strings.Join(osvAdvisory.Aliases, ","),
+		Modified: osvAdvisory.Modified,
+		Affected: []AffectedPackage{},
+	}
+	if advisory.Summary == "" {
+		advisory.Summary = strings.SplitN(strings.TrimSpace(osvAdvisory.Details), "\n", 2)[0]
+	}
+	advisory.Severity, advisory.SeverityVector = SEVERITY_UNKNOWN, ""
+	if severity, ok := osvAdvisory.DatabaseSpecific["severity"].(string); ok {
+		advisory.Severity = normaliseSeverity(severity)
+	}
+	for _, severity := range osvAdvisory.Severity {
+		if !strings.HasPrefix(severity.Type, "CVSS_") {
+			continue
+		}
+		advisory.SeverityVector = severity.Score
+		if score, ok := cvss3BaseScore(severity.Score); ok && advisory.Severity == SEVERITY_UNKNOWN {
+			advisory.Severity = cvssSeverity(score)
+		}
+	}
+
+	for _, affected := range osvAdvisory.Affected {
+		ecosystem := affected.Package.Ecosystem
+		if _, ok := purlTypes[ecosystem]; !ok {
+			continue
+		}
+		name := affected.Package.Name
+		if ecosystem == ECOSYSTEM_PYPI {
+			name = normalisePythonName(name)
+		}
+		if severity, ok := affected.DatabaseSpecific["severity"].(string); ok && advisory.Severity == SEVERITY_UNKNOWN {
+			advisory.Severity = normaliseSeverity(severity)
+		}
+		if len(affected.Versions) > 0 {
+			advisory.Affected = append(advisory.Affected, AffectedPackage{
+				AdvisoryID: advisory.ID, Ecosystem: ecosystem, Name: name, Versions: strings.Join(affected.Versions, "\n"),
+			})
+		}
+		for _, versionRange := range affected.Ranges {
+			if versionRange.Type != RANGE_SEMVER && versionRange.Type != RANGE_ECOSYSTEM {
+				continue
+			}
+			// events are ordered, each introduced version opening an interval the next fixed or last affected version closes
+			var interval *AffectedPackage
+			for _, event := range versionRange.Events {
+				if introduced, ok := event["introduced"]; ok {
+					if interval != nil {
+						advisory.Affected = append(advisory.Affected, *interval)
+					}
+					interval = &AffectedPackage{
+						AdvisoryID: advisory.ID, Ecosystem: ecosystem, Name: name, RangeType: versionRange.Type, Introduced: introduced,
+					}
+				} else if interval == nil {
+					continue
+				} else if fixed, ok := event["fixed"]; ok {
+					interval.Fixed = fixed
+				} else if lastAffected, ok := event["last_affected"]; ok {
+					interval.LastAffected = lastAffected
+				} else {
+					continue
+				}
+				if interval.Fixed != "" || interval.LastAffected != "" {
+					advisory.Affected = append(advisory.Affected, *interval)
+					interval = nil
+				}
+			}
+			if interval != nil {
+				advisory.Affected = append(advisory.Affected, *interval)
+			}
+		}
+	}
+	return advisory
+}
+
+// maps the severity names used by advisory databases onto the stored levels
+func normaliseSeverity(severity string) string {
+	severity = strings.ToUpper(strings.TrimSpace(severity))
+	switch severity {
+	case "MEDIUM":
+		return SEVERITY_MODERATE
+	case SEVERITY_CRITICAL, SEVERITY_HIGH, SEVERITY_MODERATE, SEVERITY_LOW:
+		return severity
+	}
+	return SEVERITY_UNKNOWN
+}
+
+// gets the severity level of a CVSS base score
+func cvssSeverity(score float64) string {
+	switch {
+	case score >= 9:
+		return SEVERITY_CRITICAL
+	case score >= 7:
+		return SEVERITY_HIGH
+	case score >= 4:
+		return SEVERITY_MODERATE
+	case score > 0:
+		return SEVERITY_LOW
+	}
+	return SEVERITY_UNKNOWN
+}
+
+// Computes the base score of a CVSS v3 vector (i.e. "CVSS:3.1/AV:N/AC:L/...").
+// Returns false for other CVSS versions or malformed vectors.
+func cvss3BaseScore(vector string) (float64, bool) {
+	weights := map[string]map[string]float64{
+		"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+		"AC": {"L": 0.77, "H": 0.44},
+		"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+		"UI": {"N": 0.85, "R": 0.62},
+		"C":  {"H": 0.56, "L": 0.22, "N": 0},
+		"I":  {"H": 0.56, "L": 0.22, "N": 0},
+		"A":  {"H": 0.56, "L": 0.22, "N": 0},
+	}
+	parts := strings.Split(vector, "/")
+	if !strings.HasPrefix(parts[0], "CVSS:3") {
+		return 0, false
+	}
+	metrics := map[string]string{}
+	for _, part := range parts[1:] {
+		if metric := strings.SplitN(part, ":", 2); len(metric) == 2 {
+			metrics[metric[0]] = metric[1]
+		}
+	}
+	values := map[string]float64{}
+	for metric, metricWeights := range weights {
+		weight, ok := metricWeights[metrics[metric]]
+		if !ok {
+			return 0, false
+		}
+		values[metric] = weight
+	}
+	scopeChanged := metrics["S"] == "C"
+	if !scopeChanged && metrics["S"] != "U" {
+		return 0, false
+	}
+	// privileges required weigh more when the scope changes
+	if scopeChanged && metrics["PR"] == "L" {
+		values["PR"] = 0.68
+	} else if scopeChanged && metrics["PR"] == "H" {
+		values["PR"] = 0.5
+	}
+
+	iss := 1 - (1-values["C"])*(1-values["I"])*(1-values["A"])
+	impact := 6.42 * iss
+	if scopeChanged {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	}
+	if impact <= 0 {
+		return 0, true
+	}
+	exploitability := 8.22 * values["AV"] * values["AC"] * values["PR"] * values["UI"]
+	score := impact + exploitability
+	if scopeChanged {
+		score *= 1.08
+	}
+	return cvssRoundUp(math.Min(score, 10)), true
+}
+
+// rounds a score up to one decimal place as defined by CVSS v3.1
+func cvssRoundUp(score float64) float64 {
+	scaled := int(math.Round(score * 100000))
+	if scaled%10000 == 0 {
+		return float64(scaled) / 100000
+	}
+	return float64(scaled/10000+1) / 10
+}
@@ -0,0 +1,424 @@
+// =========================================================================
+// analytics.go
+// Created: October 19, 2026
+//
+// This file takes care of the editors' analytics: the queue of submissions
+// awaiting the journal, the workload of each reviewer and how long reviews
+// and decisions take. Times come from the submission history where it was
+// recorded, and from the invitations and decisions otherwise
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	SUBROUTE_ANALYTICS = "/analytics"
+
+	ENDPOINT_QUEUE      = "/queue"
+	ENDPOINT_WORKLOADS  = "/reviewers"
+	ENDPOINT_TURNAROUND = "/turnaround"
+
+	ANALYTICS_DEFAULT_WINDOW_DAYS = 90 // window of the turnaround distributions if none is given
+)
+
+// statuses in which a submission waits on the journal, in workflow order
+var queueStatuses = []string{STATUS_SUBMITTED, STATUS_UNDER_REVIEW, STATUS_REVISIONS_REQUESTED, STATUS_RESUBMITTED}
+
+// upper bounds (exclusive) of the buckets of turnaround distributions, the last bucket being unbounded
+var turnaroundBuckets = []struct {
+	label string
+	bound time.Duration
+}{
+	{"<1d", 24 * time.Hour},
+	{"1-7d", 7 * 24 * time.Hour},
+	{"7-14d", 14 * 24 * time.Hour},
+	{"14-30d", 30 * 24 * time.Hour},
+	{">30d", 0},
+}
+
+// a time aggregated over a submission's rows (i.e. its first review)
+type eventTime struct {
+	SubmissionID uint
+	At           time.Time
+}
+
+// Describe mux routing for analytics endpoints.
+func getAnalyticsSubRoutes(r *mux.Router) {
+	analytics := r.PathPrefix(SUBROUTE_ANALYTICS).Subrouter()
+	analytics.Use(jwtMiddleware)
+
+	// Analytics routes:
+	// + GET /analytics/queue - Get the submissions waiting on the journal by status, with their age in it. Filterable by handling editor.
+	// + GET /analytics/reviewers - Get the open, completed and overdue reviews of each reviewer.
+	// + GET /analytics/turnaround - Get the distributions of the time to first review and to decision.
+	analytics.HandleFunc(ENDPOINT_QUEUE, GetSubmissionQueue).Methods(http.MethodGet)
+	analytics.HandleFunc(ENDPOINT_WORKLOADS, GetReviewerWorkloads).Methods(http.MethodGet)
+	analytics.HandleFunc(ENDPOINT_TURNAROUND, GetTurnaround).Methods(http.MethodGet)
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to get the queue of submissions waiting on the
+// journal, grouped by status, oldest in their status first. Can be filtered
+// by handling editor, "none" for the submissions without one
+// GET /analytics/queue?handlingEditors=...
+func GetSubmissionQueue(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionQueueResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view analytics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if queue, err := getSubmissionQueue(r.URL.Query()["handlingEditors"], time.Now()); err != nil {
+		log.Printf("[ERROR] could not get submission queue: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get submission queue", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else {
+		resp.Queue = queue
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to get the workload of each reviewer
+// GET /analytics/reviewers
+func GetReviewerWorkloads(w http.ResponseWriter, r *http.Request) {
+	resp := &GetReviewerWorkloadsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view analytics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if workloads, err := getReviewerWorkloads(); err != nil {
+		log.Printf("[ERROR] could not get reviewer workloads: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get reviewer workloads", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else {
+		resp.Reviewers = workloads
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to get the distributions of the time
+// submissions take to get their first review and their decision, for
+// reviews and decisions made within a window (the last 90 days by default)
+// GET /analytics/turnaround[?from={RFC3339 time}&to={RFC3339 time}]
+func GetTurnaround(w http.ResponseWriter, r *http.Request) {
+	resp := &GetTurnaroundResponse{}
+
+	query := r.URL.Query()
+	resp.To = time.Now()
+	var toErr, fromErr error
+	if to := query.Get("to"); to != "" {
+		resp.To, toErr = time.Parse(time.RFC3339, to)
+	}
+	resp.From = resp.To.AddDate(0, 0, -ANALYTICS_DEFAULT_WINDOW_DAYS)
+	if from := query.Get("from"); from != "" {
+		resp.From, fromErr = time.Parse(time.RFC3339, from)
+	}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view analytics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if toErr != nil {
+		resp.StandardResponse = StandardResponse{Message: (&BadQueryParameterError{ParamName: "to", Value: query.Get("to")}).Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if fromErr != nil || !resp.From.Before(resp.To) {
+		resp.StandardResponse = StandardResponse{Message: (&BadQueryParameterError{ParamName: "from", Value: query.Get("from")}).Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := getTurnaround(resp); err != nil {
+		log.Printf("[ERROR] could not get turnaround: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get turnaround", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Gets the submissions waiting on the journal, grouped by status. A
+// submission entered its status at its last recorded status change, or at its
+// creation if none was recorded.
+//
+// Params:
+// 	handlingEditors ([]string) : the handling editors to filter by, all submissions if empty
+// 	now (time.Time) : the time ages are computed at
+// Returns:
+// 	([]QueueStatus) : the submissions of each queue status
+// 	(error) : an error if one occurs
+func getSubmissionQueue(handlingEditors []string, now time.Time) ([]QueueStatus, error) {
+	submissions := []Submission{}
+	query := gormDb.Select("id, name, status, handling_editor_id, created_at").Where("status IN ?", queueStatuses)
+	if err := filterByHandlingEditor(query, handlingEditors).Find(&submissions).Error; err != nil {
+		return nil, err
+	}
+	if len(submissions) == 0 {
+		return groupQueue(submissions, nil, now), nil
+	}
+	submissionIDs := []uint{}
+	for _, submission := range submissions {
+		submissionIDs = append(submissionIDs, submission.ID)
+	}
+	// the last change led to the current status
+	changes := []eventTime{}
+	if err := gormDb.Model(&SubmissionEvent{}).Select("submission_id, MAX(created_at) AS at").
+		Where("type = ? AND submission_id IN ?", EVENT_STATUS_CHANGED, submissionIDs).
+		Group("submission_id").Scan(&changes).Error; err != nil {
+		return nil, err
+	}
+	enteredAt := make(map[uint]time.Time)
+	for _, change := range changes {
+		enteredAt[change.SubmissionID] = change.At
+	}
+	return groupQueue(submissions, enteredAt, now), nil
+}
+
+// groups submissions by queue status, oldest in their status first
+func groupQueue(submissions []Submission, enteredAt map[uint]time.Time, now time.Time) []QueueStatus {
+	entries := make(map[string][]QueueEntry)
+	for _, submission := range submissions {
+		entered, ok := enteredAt[submission.ID]
+		if !ok {
+			entered = submission.CreatedAt
+		}
+		entries[submission.Status] = append(entries[submission.Status], QueueEntry{
+			SubmissionID:     submission.ID,
+			SubmissionName:   submission.Name,
+			HandlingEditorID: submission.HandlingEditorID,
+			EnteredAt:        entered,
+			AgeHours:         now.Sub(entered).Hours(),
+		})
+	}
+	queue := []QueueStatus{}
+	for _, status := range queueStatuses {
+		statusEntries := entries[status]
+		if statusEntries == nil {
+			statusEntries = []QueueEntry{}
+		}
+		sort.SliceStable(statusEntries, func(i, j int) bool {
+			return statusEntries[i].EnteredAt.Before(statusEntries[j].EnteredAt)
+		})
+		queue = append(queue, QueueStatus{Status: status, Count: len(statusEntries), Submissions: statusEntries})
+	}
+	return queue
+}
+
+// Gets the open, completed and overdue reviews of every reviewer, most loaded first.
+//
+// Returns:
+// 	([]ReviewerWorkload) : the workload of each reviewer
+// 	(error) : an error if one occurs
+func getReviewerWorkloads() ([]ReviewerWorkload, error) {
+	reviewers := []GlobalUser{}
+	if err := gormDb.Where("user_type IN ?", []int{USERTYPE_REVIEWER, USERTYPE_REVIEWER_PUBLISHER}).
+		Find(&reviewers).Error; err != nil {
+		return nil, err
+	}
+	invitations := []ReviewInvitation{}
+	if err := gormDb.Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	counts := countWorkloads(invitations)
+	workloads := []ReviewerWorkload{}
+	for _, reviewer := range reviewers {
+		workload := ReviewerWorkload{ReviewerID: reviewer.ID, FirstName: reviewer.FirstName, LastName: reviewer.LastName}
+		if count, ok := counts[reviewer.ID]; ok {
+			workload.Open, workload.Completed, workload.Overdue = count.Open, count.Completed, count.Overdue
+		}
+		workloads = append(workloads, workload)
+	}
+	sort.SliceStable(workloads, func(i, j int) bool {
+		if workloads[i].Open != workloads[j].Open {
+			return workloads[i].Open > workloads[j].Open
+		}
+		return workloads[i].ReviewerID < workloads[j].ReviewerID
+	})
+	return workloads, nil
+}
+
+// counts the open, completed and overdue reviews of each reviewer from their
+// invitations. Open reviews are pending or accepted invitations without a
+// review, of which overdue reviews are past their deadline
+func countWorkloads(invitations []ReviewInvitation) map[string]*ReviewerWorkload {
+	counts := make(map[string]*ReviewerWorkload)
+	for _, invitation := range invitations {
+		count, ok := counts[invitation.ReviewerID]
+		if !ok {
+			count = &ReviewerWorkload{ReviewerID: invitation.ReviewerID}
+			counts[invitation.ReviewerID] = count
+		}
+		if invitation.CompletedAt != nil {
+			count.Completed++
+		} else if invitation.Status == INVITATION_INVITED || invitation.Status == INVITATION_ACCEPTED {
+			count.Open++
+			if invitation.Overdue {
+				count.Overdue++
+			}
+		}
+	}
+	return counts
+}
+
+// Fills in the distributions of the time submissions took to get their first
+// review and their first decision, for those made in the response's window.
+// First reviews are taken from the submission history, or from completed
+// invitations for submissions reviewed before it was recorded.
+//
+// Params:
+// 	resp (*GetTurnaroundResponse) : the response, with its window set
+// Returns:
+// 	(error) : an error if one occurs
+func getTurnaround(resp *GetTurnaroundResponse) error {
+	reviews := gormDb.Raw("? UNION ALL ?",
+		gormDb.Model(&SubmissionEvent{}).Select("submission_id, created_at AS at").Where("type = ?", EVENT_REVIEW_SUBMITTED),
+		gormDb.Model(&ReviewInvitation{}).Select("submission_id, completed_at AS at").Where("completed_at IS NOT NULL"))
+	firstReviews, err := getFirstTimesInWindow(reviews, resp.From, resp.To)
+	if err != nil {
+		return err
+	}
+	firstDecisions, err := getFirstTimesInWindow(gormDb.Model(&SubmissionDecision{}).
+		Select("submission_id, created_at AS at"), resp.From, resp.To)
+	if err != nil {
+		return err
+	}
+
+	submissionIDs := []uint{}
+	for submissionID := range firstReviews {
+		submissionIDs = append(submissionIDs, submissionID)
+	}
+	for submissionID := range firstDecisions {
+		submissionIDs = append(submissionIDs, submissionID)
+	}
+	submissions := []Submission{}
+	if len(submissionIDs) > 0 {
+		if err := gormDb.Select("id, created_at").Where("id IN ?", submissionIDs).Find(&submissions).Error; err != nil {
+			return err
+		}
+	}
+
+	toReview, toDecision := []time.Duration{}, []time.Duration{}
+	for _, submission := range submissions {
+		if at, ok := firstReviews[submission.ID]; ok {
+			toReview = append(toReview, at.Sub(submission.CreatedAt))
+		}
+		if at, ok := firstDecisions[submission.ID]; ok {
+			toDecision = append(toDecision, at.Sub(submission.CreatedAt))
+		}
+	}
+	resp.TimeToFirstReview = getDistribution(toReview)
+	resp.TimeToDecision = getDistribution(toDecision)
+	return nil
+}
+
+// Gets the first time of each submission, from a query selecting times as
+// "at" by submission, keeping the submissions whose first time is in the
+// window [from, to).
+//
+// Params:
+// 	query (*gorm.DB) : the query selecting submission_id and at
+// 	from (time.Time) : the start of the window
+// 	to (time.Time) : the end of the window, excluded
+// Returns:
+// 	(map[uint]time.Time) : the first time of each submission in the window
+// 	(error) : an error if one occurs
+func getFirstTimesInWindow(query *gorm.DB, from time.Time, to time.Time) (map[uint]time.Time, error) {
+	firsts := []eventTime{}
+	if err := gormDb.Table("(?) AS times", query).Select("submission_id, MIN(at) AS at").Group("submission_id").
+		Having("MIN(at) >= ? AND MIN(at) < ?", from, to).Scan(&firsts).Error; err != nil {
+		return nil, err
+	}
+	times := make(map[uint]time.Time)
+	for _, first := range firsts {
+		times[first.SubmissionID] = first.At
+	}
+	return times, nil
+}
+
+// Summarises durations as a distribution in hours, with nearest-rank
+// percentiles and counts per bucket of days.
+//
+// Params:
+// 	durations ([]time.Duration) : the durations
+// Returns:
+// 	(Distribution) : the distribution, with zero statistics if there are no durations
+func getDistribution(durations []time.Duration) Distribution {
+	distribution := Distribution{Count: len(durations), Buckets: make([]DistributionBucket, len(turnaroundBuckets))}
+	for i, bucket := range turnaroundBuckets {
+		distribution.Buckets[i].Label = bucket.label
+	}
+	if len(durations) == 0 {
+		return distribution
+	}
+
+	sorted := append([]time.Duration{}, durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	percentile := func(p float64) float64 {
+		rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+		return sorted[rank].Hours()
+	}
+	total := time.Duration(0)
+	for _, duration := range sorted {
+		total += duration
+		for i, bucket := range turnaroundBuckets {
+			if bucket.bound == 0 || duration < bucket.bound {
+				distribution.Buckets[i].Count++
+				break
+			}
+		}
+	}
+	distribution.MinHours = sorted[0].Hours()
+	distribution.MedianHours = percentile(0.5)
+	distribution.P90Hours = percentile(0.9)
+	distribution.MaxHours = sorted[len(sorted)-1].Hours()
+	distribution.MeanHours = total.Hours() / float64(len(sorted))
+	return distribution
+}
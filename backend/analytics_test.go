@@ -0,0 +1,90 @@
+// =====================================
+// analytics_test.go
+// Created: October 19, 2026
+//
+// test file for analytics.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that submissions are grouped by status, aged from their last status change
+func TestGroupQueue(t *testing.T) {
+	now := time.Now()
+	created := now.Add(-48 * time.Hour)
+	submissions := []Submission{
+		{Model: gorm.Model{ID: 1, CreatedAt: created}, Status: STATUS_UNDER_REVIEW},
+		{Model: gorm.Model{ID: 2, CreatedAt: created}, Status: STATUS_UNDER_REVIEW},
+		{Model: gorm.Model{ID: 3, CreatedAt: created}, Status: STATUS_SUBMITTED},
+	}
+	queue := groupQueue(submissions, map[uint]time.Time{1: now.Add(-time.Hour)}, now)
+	if !assert.Len(t, queue, len(queueStatuses), "wrong number of statuses") {
+		return
+	}
+	switch {
+	case !assert.Equal(t, STATUS_SUBMITTED, queue[0].Status, "statuses out of order"),
+		!assert.Equal(t, 1, queue[0].Count, "wrong submitted count"),
+		!assert.Equal(t, 2, queue[1].Count, "wrong under review count"),
+		!assert.Equal(t, uint(2), queue[1].Submissions[0].SubmissionID, "oldest submission not first"),
+		!assert.Equal(t, 48.0, queue[1].Submissions[0].AgeHours, "age not from creation"),
+		!assert.Equal(t, 1.0, queue[1].Submissions[1].AgeHours, "age not from status change"),
+		!assert.Empty(t, queue[2].Submissions, "empty status has submissions"):
+		return
+	}
+}
+
+// tests that invitations are counted by state
+func TestCountWorkloads(t *testing.T) {
+	completedAt := time.Now()
+	counts := countWorkloads([]ReviewInvitation{
+		{ReviewerID: "reviewer", Status: INVITATION_INVITED},
+		{ReviewerID: "reviewer", Status: INVITATION_ACCEPTED, Overdue: true},
+		{ReviewerID: "reviewer", Status: INVITATION_ACCEPTED, CompletedAt: &completedAt},
+		{ReviewerID: "reviewer", Status: INVITATION_DECLINED},
+		{ReviewerID: "other", Status: INVITATION_EXPIRED},
+	})
+	if !assert.Contains(t, counts, "reviewer", "reviewer not counted") {
+		return
+	}
+	assert.Equal(t, ReviewerWorkload{ReviewerID: "reviewer", Open: 2, Completed: 1, Overdue: 1}, *counts["reviewer"], "wrong workload")
+	assert.Equal(t, ReviewerWorkload{ReviewerID: "other"}, *counts["other"], "closed invitation counted")
+}
+
+// tests the statistics and buckets of distributions
+func TestGetDistribution(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		distribution := getDistribution(nil)
+		assert.Equal(t, 0, distribution.Count, "durations counted")
+		assert.Len(t, distribution.Buckets, len(turnaroundBuckets), "buckets missing")
+	})
+
+	t.Run("Durations", func(t *testing.T) {
+		day := 24 * time.Hour
+		distribution := getDistribution([]time.Duration{40 * day, 12 * time.Hour, 3 * day, 2 * day, 10 * day})
+		switch {
+		case !assert.Equal(t, 5, distribution.Count, "wrong count"),
+			!assert.Equal(t, 12.0, distribution.MinHours, "wrong minimum"),
+			!assert.Equal(t, 72.0, distribution.MedianHours, "wrong median"),
+			!assert.Equal(t, 960.0, distribution.P90Hours, "wrong 90th percentile"),
+			!assert.Equal(t, 960.0, distribution.MaxHours, "wrong maximum"),
+			!assert.Equal(t, 266.4, distribution.MeanHours, "wrong mean"):
+			return
+		}
+		counts := []int{}
+		for _, bucket := range distribution.Buckets {
+			counts = append(counts, bucket.Count)
+		}
+		assert.Equal(t, []int{1, 2, 1, 0, 1}, counts, "wrong bucket counts")
+	})
+}
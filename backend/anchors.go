@@ -0,0 +1,228 @@
+// =========================================================================
+// anchors.go
+// Created: October 19, 2026
+//
+// This file takes care of keeping comments anchored across versions: when a
+// submission is resubmitted, the comments on its files are moved onto the
+// new version of each file, their lines mapped through a line diff. Comments
+// on lines which were changed or removed are flagged as outdated
+// =========================================================================
+
+package main
+
+import (
+	"encoding/base64"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// largest number of cells of the line diff table, past which the changed
+// middle of a file is treated as entirely replaced
+const MAX_DIFF_CELLS = 4000000
+
+// ------
+// Helper Functions
+// ------
+
+// Moves the comments on the files of a submission's previous version onto
+// the files with the same path in its new version. Comments keep their
+// original anchor, and are flagged as outdated if any of their lines were
+// changed or removed. Replies are moved onto the lines of the comment starting
+// their thread. Comments on files removed from the new version stay
+// where they are, flagged as outdated.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to move the comments in
+// 	submission (*Submission) : the submission, with its new version and the new version's files (with IDs) set
+// Returns:
+// 	(error) : an error if one occurs
+func reanchorComments(tx *gorm.DB, submission *Submission) error {
+	oldFiles := []File{}
+	if err := tx.Where("submission_id = ? AND version = ?", submission.ID, submission.Version-1).
+		Find(&oldFiles).Error; err != nil {
+		return err
+	}
+	newFiles := make(map[string]*File)
+	for i := range submission.Files {
+		newFiles[submission.Files[i].Path] = &submission.Files[i]
+	}
+	submissionPath := getSubmissionDirectoryPath(*submission)
+	for _, oldFile := range oldFiles {
+		comments := []Comment{}
+		if err := tx.Where("file_id = ?", oldFile.ID).Find(&comments).Error; err != nil {
+			return err
+		} else if len(comments) == 0 {
+			continue
+		}
+		newFile, ok := newFiles[oldFile.Path]
+		if !ok {
+			if err := tx.Model(&Comment{}).Where("file_id = ?", oldFile.ID).Update("outdated", true).Error; err != nil {
+				return err
+			}
+			continue
+		}
+
+		content, err := getFileContent(filepath.Join(submissionPath, fmt.Sprint(oldFile.ID)))
+		if err != nil {
+			return err
+		}
+		newLines := fileLines(newFile.Base64Value)
+		mapping := mapLines(fileLines(content), newLines)
+		byID := make(map[uint]*Comment)
+		for i := range comments {
+			byID[comments[i].ID] = &comments[i]
+		}
+		for _, comment := range comments {
+			updates := map[string]interface{}{"file_id": newFile.ID}
+			if comment.OriginalFileID == nil {
+				updates["original_file_id"] = oldFile.ID
+				updates["original_start_line"] = comment.StartLine
+				updates["original_end_line"] = comment.EndLine
+			}
+			// replies follow the comment starting their thread
+			root := threadRoot(byID, &comment)
+			start, end, outdated := reanchor(mapping, len(newLines), root.StartLine, root.EndLine)
+			updates["start_line"] = start
+			updates["end_line"] = end
+			updates["outdated"] = root.Outdated || outdated
+			if err := tx.Model(&Comment{}).Where("id = ?", comment.ID).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// gets the comment starting a comment's thread, among the comments of the
+// same file. Replies whose parent is not on the file count as starting their thread
+func threadRoot(byID map[uint]*Comment, comment *Comment) *Comment {
+	for seen := 0; comment.ParentID != nil && seen < len(byID); seen++ {
+		parent, ok := byID[*comment.ParentID]
+		if !ok {
+			break
+		}
+		comment = parent
+	}
+	return comment
+}
+
+// splits a file's content into lines, decoding it if it is base64 encoded
+func fileLines(base64Value string) []string {
+	content := base64Value
+	if decoded, err := base64.StdEncoding.DecodeString(base64Value); err == nil {
+		content = string(decoded)
+	}
+	if content == "" {
+		return []string{}
+	}
+	return strings.Split(strings.TrimSuffix(strings.ReplaceAll(content, "\r\n", "\n"), "\n"), "\n")
+}
+
+// Maps the lines of a file's old version onto its new version, using the
+// longest common subsequence of their lines. Changed lines count as removed.
+//
+// Params:
+// 	oldLines ([]string) : the lines of the old version
+// 	newLines ([]string) : the lines of the new version
+// Returns:
+// 	([]int) : the index in the new version of each old line, -1 if it was removed
+func mapLines(oldLines []string, newLines []string) []int {
+	mapping := make([]int, len(oldLines))
+	for i := range mapping {
+		mapping[i] = -1
+	}
+	// lines shared at the start and end of both versions map onto each other directly
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		mapping[prefix] = prefix
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		mapping[len(oldLines)-1-suffix] = len(newLines) - 1 - suffix
+		suffix++
+	}
+	oldMiddle := oldLines[prefix : len(oldLines)-suffix]
+	newMiddle := newLines[prefix : len(newLines)-suffix]
+	if len(oldMiddle) == 0 || len(newMiddle) == 0 || len(oldMiddle)*len(newMiddle) > MAX_DIFF_CELLS {
+		return mapping
+	}
+
+	// lengths of the longest common subsequences of the middles' suffixes
+	lengths := make([][]int, len(oldMiddle)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newMiddle)+1)
+	}
+	for i := len(oldMiddle) - 1; i >= 0; i-- {
+		for j := len(newMiddle) - 1; j >= 0; j-- {
+			if oldMiddle[i] == newMiddle[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	for i, j := 0, 0; i < len(oldMiddle) && j < len(newMiddle); {
+		if oldMiddle[i] == newMiddle[j] {
+			mapping[prefix+i] = prefix + j
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return mapping
+}
+
+// Maps a comment's lines onto a file's new version. Lines are numbered from
+// 1, and comments on lines 0 to 0 are on the whole file. A comment is
+// outdated if any of its lines were removed, and is anchored on its remaining
+// lines, or where its lines were if none remain.
+//
+// Params:
+// 	mapping ([]int) : the line mapping between the file's versions (see mapLines)
+// 	newLineCount (int) : the number of lines of the new version
+// 	start (int) : the comment's first line
+// 	end (int) : the comment's last line
+// Returns:
+// 	(int) : the comment's first line in the new version
+// 	(int) : the comment's last line in the new version
+// 	(bool) : true if the comment is outdated
+func reanchor(mapping []int, newLineCount int, start int, end int) (int, int, bool) {
+	if start == 0 && end == 0 {
+		return 0, 0, false
+	} else if start < 1 || end < start || end > len(mapping) {
+		return start, end, true // the anchor was already outside of the file
+	}
+	newStart, newEnd, outdated := -1, -1, false
+	for line := start; line <= end; line++ {
+		if mapped := mapping[line-1]; mapped < 0 {
+			outdated = true
+		} else {
+			if newStart < 0 {
+				newStart = mapped + 1
+			}
+			newEnd = mapped + 1
+		}
+	}
+	if newStart > 0 {
+		return newStart, newEnd, outdated
+	}
+	// anchors on the first remaining line after the removed lines, else on the last line
+	position := newLineCount
+	for line := end; line < len(mapping); line++ {
+		if mapping[line] >= 0 {
+			position = mapping[line] + 1
+			break
+		}
+	}
+	return position, position, true
+}
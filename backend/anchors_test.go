@@ -0,0 +1,107 @@
+// =====================================
+// anchors_test.go
+// Created: October 19, 2026
+//
+// test file for anchors.go
+// =====================================
+
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that base64 encoded and plain file contents are split into lines
+func TestFileLines(t *testing.T) {
+	content := "first\r\nsecond\n"
+	switch {
+	case !assert.Equal(t, []string{"first", "second"}, fileLines(base64.StdEncoding.EncodeToString([]byte(content))), "wrong lines of encoded content"),
+		!assert.Equal(t, []string{"first", "second"}, fileLines(content), "wrong lines of plain content"),
+		!assert.Empty(t, fileLines(""), "lines in empty content"):
+		return
+	}
+}
+
+// tests that unchanged lines are mapped onto the new version, and changed or removed lines are not
+func TestMapLines(t *testing.T) {
+	testCases := []struct {
+		name     string
+		oldLines []string
+		newLines []string
+		mapping  []int
+	}{
+		{"unchanged", []string{"a", "b"}, []string{"a", "b"}, []int{0, 1}},
+		{"inserted line", []string{"a", "b", "c"}, []string{"a", "x", "b", "c"}, []int{0, 2, 3}},
+		{"removed line", []string{"a", "b", "c"}, []string{"a", "c"}, []int{0, -1, 1}},
+		{"changed line", []string{"a", "b", "c"}, []string{"a", "B", "c"}, []int{0, -1, 2}},
+		{"moved block", []string{"a", "b", "c", "d"}, []string{"c", "a", "b", "d"}, []int{1, 2, -1, 3}},
+		{"emptied file", []string{"a", "b"}, []string{}, []int{-1, -1}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.mapping, mapLines(testCase.oldLines, testCase.newLines), "wrong line mapping")
+		})
+	}
+}
+
+// tests that comments move with their lines, and are outdated if their lines were removed
+func TestReanchor(t *testing.T) {
+	// old lines 2 and 4 were removed, a line was inserted before old line 1
+	mapping := []int{1, -1, 2, -1, 3}
+	testCases := []struct {
+		name       string
+		start, end int
+		newStart   int
+		newEnd     int
+		outdated   bool
+	}{
+		{"whole file", 0, 0, 0, 0, false},
+		{"moved line", 3, 3, 3, 3, false},
+		{"partly removed", 1, 3, 2, 3, true},
+		{"removed line", 4, 4, 4, 4, true},
+		{"outside of file", 6, 7, 6, 7, true},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			start, end, outdated := reanchor(mapping, 4, testCase.start, testCase.end)
+			switch {
+			case !assert.Equal(t, testCase.newStart, start, "wrong first line"),
+				!assert.Equal(t, testCase.newEnd, end, "wrong last line"),
+				!assert.Equal(t, testCase.outdated, outdated, "wrong outdated flag"):
+				return
+			}
+		})
+	}
+	// lines removed at the end of the file are anchored on its last line
+	start, end, outdated := reanchor([]int{0, -1}, 1, 2, 2)
+	switch {
+	case !assert.Equal(t, 1, start, "wrong first line"),
+		!assert.Equal(t, 1, end, "wrong last line"),
+		!assert.True(t, outdated, "comment not outdated"):
+		return
+	}
+}
+
+// tests that replies are anchored through the comment starting their thread
+func TestThreadRoot(t *testing.T) {
+	rootID, replyID, missingID := uint(1), uint(2), uint(9)
+	root := &Comment{StartLine: 3, EndLine: 4}
+	reply := &Comment{ParentID: &rootID, StartLine: 7, EndLine: 8}
+	nested := &Comment{ParentID: &replyID}
+	orphan := &Comment{ParentID: &missingID}
+	byID := map[uint]*Comment{rootID: root, replyID: reply}
+	switch {
+	case !assert.Same(t, root, threadRoot(byID, root), "wrong root of thread root"),
+		!assert.Same(t, root, threadRoot(byID, reply), "wrong root of reply"),
+		!assert.Same(t, root, threadRoot(byID, nested), "wrong root of nested reply"),
+		!assert.Same(t, orphan, threadRoot(byID, orphan), "wrong root of reply to another file's comment"):
+		return
+	}
+}
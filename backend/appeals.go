@@ -0,0 +1,370 @@
+// =========================================================================
+// appeals.go
+// Created: October 19, 2026
+//
+// This file takes care of appeals against rejections: authors rebut specific
+// reviews of their rejected submission, and an editor other than the one who
+// rejected it either upholds the rejection or reopens the submission into a
+// new review round
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_APPEAL         = "/appeal"
+	ENDPOINT_RESOLVE_APPEAL = "/resolve"
+	ENDPOINT_APPEALS        = "/appeals"
+
+	// statuses of appeals
+	APPEAL_PENDING  = "pending"  // waiting for an editor
+	APPEAL_UPHELD   = "upheld"   // the rejection stands
+	APPEAL_REOPENED = "reopened" // the submission is reviewed again
+
+	// reason recorded on the reviews archived when a submission is reopened
+	APPEAL_ARCHIVE_REASON = "submission reopened on appeal"
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for authors to appeal the rejection of their submission
+// POST /submission/{id}/appeal
+func PostFileAppeal(w http.ResponseWriter, r *http.Request) {
+	resp := &FileAppealResponse{}
+	resp.StandardResponse = StandardResponse{Message: "Appeal filed successfully", Error: false}
+	reqBody := &AppealBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.ID, err = ControllerFileAppeal(submissionID, reqBody, ctx); err != nil {
+		resp.StandardResponse = *appealErrorResponse(w, err, "could not file appeal")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to resolve the pending appeal against a
+// submission's rejection
+// POST /submission/{id}/appeal/resolve
+func PostResolveAppeal(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Appeal resolved successfully", Error: false}
+	reqBody := &ResolveAppealBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to resolve appeals.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerResolveAppeal(submissionID, reqBody.Reopen, reqBody.Resolution, ctx.ID); err != nil {
+		resp = appealErrorResponse(w, err, "could not resolve appeal")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function to get the appeals against a submission's rejections, for
+// editors and the submission's authors
+// GET /submission/{id}/appeals
+func GetAppeals(w http.ResponseWriter, r *http.Request) {
+	resp := &GetAppealsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if resp.Appeals, err = getAppeals(submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Only editors and authors can view a submission's appeals.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			resp.StandardResponse = *appealErrorResponse(w, err, "could not get appeals")
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which files an author's appeal against the latest rejection of
+// their submission. A rejection can only be appealed once.
+//
+// Params:
+// 	submissionID (uint) : the rejected submission
+// 	r (*AppealBody) : the author's statement and rebuttals of reviews
+// 	ctx (*RequestContext) : the logged in user, who must be an author
+// Returns:
+// 	(uint) : the ID of the appeal
+// 	(error) : an *AppealNotAllowedError if the submission cannot be appealed, another error if one occurs
+func ControllerFileAppeal(submissionID uint, r *AppealBody, ctx *RequestContext) (uint, error) {
+	appeal := &Appeal{
+		SubmissionID: submissionID,
+		AuthorID:     ctx.ID,
+		Statement:    r.Statement,
+		Status:       APPEAL_PENDING,
+	}
+	err := gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that concurrent appeals of the same rejection are checked one after the other
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		} else if !isUserInList(ctx.ID, submission.Authors) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		} else if submission.Status != STATUS_REJECTED {
+			return &AppealNotAllowedError{SubmissionID: submissionID, Reason: "it is not rejected"}
+		}
+		mode, err := getReviewMode(tx, submission)
+		if err != nil {
+			return err
+		}
+		aliases, err := getSubmissionAliases(tx, submission, mode, ctx)
+		if err != nil {
+			return err
+		}
+		rebuttals, unknown := resolveRebuttals(currentRoundReviews(submission), aliases, r.Rebuttals)
+		if unknown != "" {
+			return &NoReviewError{UserID: unknown, SubmissionID: submissionID}
+		}
+		appeal.Rebuttals = rebuttals
+
+		decision := &SubmissionDecision{}
+		if res := tx.Where("submission_id = ?", submissionID).Order("id DESC").Limit(1).Find(decision); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 || decision.Status != STATUS_REJECTED {
+			return &AppealNotAllowedError{SubmissionID: submissionID, Reason: "its rejection was not recorded"}
+		} else if res := tx.Select("id").Where("decision_id = ?", decision.ID).Limit(1).Find(&Appeal{}); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected > 0 {
+			return &AppealNotAllowedError{SubmissionID: submissionID, Reason: "its rejection was already appealed"}
+		}
+		appeal.DecisionID = decision.ID
+		if err := tx.Create(appeal).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, submissionID, EVENT_APPEAL_FILED, ctx.ID, false,
+			map[string]string{"appeal": strconv.Itoa(int(appeal.ID))})
+	})
+	return appeal.ID, err
+}
+
+// Controller which resolves the pending appeal against a submission's
+// rejection. Reopening the submission archives the reviews of the rejected
+// version and starts a new review round with the same reviewers.
+//
+// Params:
+// 	submissionID (uint) : the appealed submission
+// 	reopen (bool) : true to reopen the submission, false to uphold its rejection
+// 	resolution (string) : the editor's reasons for the outcome
+// 	editorID (string) : the editor resolving the appeal, who cannot be the one who rejected the submission
+// Returns:
+// 	(error) : a *NoAppealError if there is no pending appeal, another error if one occurs
+func ControllerResolveAppeal(submissionID uint, reopen bool, resolution string, editorID string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that an appeal cannot be resolved twice at once
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		}
+		appeal := &Appeal{}
+		decision := &SubmissionDecision{}
+		if res := tx.Where("submission_id = ? AND status = ?", submissionID, APPEAL_PENDING).Limit(1).Find(appeal); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoAppealError{SubmissionID: submissionID}
+		} else if err := tx.First(decision, appeal.DecisionID).Error; err != nil {
+			return err
+		} else if decision.EditorID == editorID {
+			return &DecidingEditorError{EditorID: editorID, SubmissionID: submissionID}
+		}
+
+		resolvedAt := time.Now()
+		appeal.Status = APPEAL_UPHELD
+		if reopen {
+			appeal.Status = APPEAL_REOPENED
+		}
+		appeal.HandlerID = editorID
+		appeal.Resolution = resolution
+		appeal.ResolvedAt = &resolvedAt
+		if err := tx.Omit("Rebuttals").Save(appeal).Error; err != nil {
+			return err
+		} else if err := recordEvent(tx, submissionID, EVENT_APPEAL_RESOLVED, editorID, false,
+			map[string]string{"appeal": strconv.Itoa(int(appeal.ID)), "outcome": appeal.Status}); err != nil {
+			return err
+		} else if !reopen {
+			return nil
+		}
+
+		// reopens the submission into a new review round: the rejected version's
+		// reviews no longer count, and its reviewers get a new deadline
+		status := STATUS_UNDER_REVIEW
+		if len(submission.Reviewers) == 0 {
+			status = STATUS_SUBMITTED
+		}
+		if err := writeSubmissionStatus(tx, submission, status, editorID); err != nil {
+			return err
+		} else if err := resetReviewDeadlines(tx, submissionID); err != nil {
+			return err
+		}
+		archived := false
+		for _, review := range currentRoundReviews(submission) {
+			review.ArchivedAt = &resolvedAt
+			review.ArchiveReason = APPEAL_ARCHIVE_REASON
+			archived = true
+		}
+		// metadata is written last so that the database changes are rolled back if it fails
+		if archived {
+			return addMetaData(submission)
+		}
+		return nil
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the response for errors filing, resolving or getting appeals, setting
+// the matching status code
+func appealErrorResponse(w http.ResponseWriter, err error, action string) *StandardResponse {
+	switch err.(type) {
+	case *NoSubmissionError, *NoAppealError, *NoReviewError:
+		w.WriteHeader(http.StatusNotFound)
+	case *WrongPermissionsError, *DecidingEditorError:
+		w.WriteHeader(http.StatusUnauthorized)
+	case *AppealNotAllowedError:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		log.Printf("[ERROR] %s: %v\n", action, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return &StandardResponse{Message: "Internal Server Error - " + action, Error: true}
+	}
+	return &StandardResponse{Message: err.Error(), Error: true}
+}
+
+// Gets the appeals against a submission's rejections, oldest first, hiding
+// the reviewers rebutted from authors under blind review.
+//
+// Params:
+// 	submissionID (uint) : the submission
+// 	ctx (*RequestContext) : the logged in user, who must be an editor or author
+// Returns:
+// 	([]Appeal) : the appeals, with their rebuttals set
+// 	(error) : a *WrongPermissionsError if the user cannot see the appeals, another error if one occurs
+func getAppeals(submissionID uint, ctx *RequestContext) ([]Appeal, error) {
+	submission := &Submission{}
+	if res := gormDb.Preload("Authors").Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoSubmissionError{ID: submissionID}
+	} else if ctx.UserType != USERTYPE_EDITOR && !isUserInList(ctx.ID, submission.Authors) {
+		return nil, &WrongPermissionsError{userID: ctx.ID}
+	}
+	mode, err := getReviewMode(gormDb, submission)
+	if err != nil {
+		return nil, err
+	}
+	appeals := []Appeal{}
+	if err := gormDb.Preload("Rebuttals").Where("submission_id = ?", submissionID).Order("id").Find(&appeals).Error; err != nil {
+		return nil, err
+	}
+	aliases, err := getSubmissionAliases(gormDb, submission, mode, ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, appeal := range appeals {
+		for i, rebuttal := range appeal.Rebuttals {
+			if alias, ok := aliases[rebuttal.ReviewerID]; ok {
+				appeal.Rebuttals[i].ReviewerID = alias
+			}
+		}
+	}
+	return appeals, nil
+}
+
+// Matches an author's rebuttals to the reviews they are against. Authors
+// refer to reviewers by ID, or only by alias if the reviewers are hidden from
+// them, so that guessing a hidden reviewer's ID does not confirm who they are.
+//
+// Params:
+// 	reviews ([]*Review) : the reviews of the rejected version
+// 	aliases (map[string]string) : the aliases of the identities hidden from the author
+// 	rebuttals ([]RebuttalBody) : the author's rebuttals
+// Returns:
+// 	([]AppealRebuttal) : the rebuttals, against the reviewers' IDs
+// 	(string) : the first reviewer referred to who has no review, empty if there is none
+func resolveRebuttals(reviews []*Review, aliases map[string]string, rebuttals []RebuttalBody) ([]AppealRebuttal, string) {
+	reviewers := make(map[string]string)
+	for _, review := range reviews {
+		if alias, ok := aliases[review.ReviewerID]; ok {
+			reviewers[alias] = review.ReviewerID
+		} else {
+			reviewers[review.ReviewerID] = review.ReviewerID
+		}
+	}
+	resolved := []AppealRebuttal{}
+	for _, rebuttal := range rebuttals {
+		reviewerID, ok := reviewers[rebuttal.Reviewer]
+		if !ok {
+			return nil, rebuttal.Reviewer
+		}
+		resolved = append(resolved, AppealRebuttal{ReviewerID: reviewerID, Rebuttal: rebuttal.Rebuttal})
+	}
+	return resolved, ""
+}
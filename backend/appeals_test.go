@@ -0,0 +1,51 @@
+// =====================================
+// appeals_test.go
+// Created: October 19, 2026
+//
+// test file for appeals.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that rebuttals are matched to reviews by reviewer ID or alias
+func TestResolveRebuttals(t *testing.T) {
+	reviews := []*Review{{ReviewerID: "reviewer1"}, {ReviewerID: "reviewer2"}}
+	aliases := map[string]string{"reviewer2": "Reviewer 2", "reviewer3": "Reviewer 3"} // reviewer1 is not hidden
+
+	t.Run("By ID and alias", func(t *testing.T) {
+		rebuttals, unknown := resolveRebuttals(reviews, aliases, []RebuttalBody{
+			{Reviewer: "Reviewer 2", Rebuttal: "The tests do pass."},
+			{Reviewer: "reviewer1", Rebuttal: "The code is documented."},
+		})
+		switch {
+		case !assert.Empty(t, unknown, "known reviewer not matched"),
+			!assert.Len(t, rebuttals, 2, "wrong number of rebuttals"):
+			return
+		}
+		assert.Equal(t, AppealRebuttal{ReviewerID: "reviewer2", Rebuttal: "The tests do pass."}, rebuttals[0], "wrong rebuttal")
+		assert.Equal(t, AppealRebuttal{ReviewerID: "reviewer1", Rebuttal: "The code is documented."}, rebuttals[1], "wrong rebuttal")
+	})
+
+	t.Run("Reviewer without review", func(t *testing.T) {
+		_, unknown := resolveRebuttals(reviews, aliases, []RebuttalBody{
+			{Reviewer: "reviewer1", Rebuttal: "rebuttal"},
+			{Reviewer: "Reviewer 3", Rebuttal: "rebuttal"},
+		})
+		assert.Equal(t, "Reviewer 3", unknown, "rebutted a review which does not exist")
+	})
+
+	t.Run("Hidden reviewer by ID", func(t *testing.T) {
+		_, unknown := resolveRebuttals(reviews, aliases, []RebuttalBody{{Reviewer: "reviewer2", Rebuttal: "rebuttal"}})
+		assert.Equal(t, "reviewer2", unknown, "hidden reviewer's ID confirmed")
+	})
+}
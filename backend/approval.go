@@ -14,15 +14,19 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 const (
 	ENDPOINT_ASSIGN_REVIEWERS = "/assignreviewers"
 	ENPOINT_REVIEW            = "/review"
 	ENDPOINT_CHANGE_STATUS    = "/approve"
+	ENDPOINT_EDIT_REVIEW      = "/review/edit"
+	ENDPOINT_WITHDRAW_REVIEW  = "/review/withdraw"
 )
 
 // ------------
@@ -34,7 +38,7 @@ const (
 // uses addReviewers in submissions.go
 // POST /submission/{id}/assignreviewers
 func PostAssignReviewers(w http.ResponseWriter, r *http.Request) {
-	resp := &StandardResponse{}
+	resp := &AssignReviewersResponse{}
 	reqBody := &AssignReviewersBody{}
 
 	// gets the submission ID from the vars and user details from request context
@@ -42,37 +46,48 @@ func PostAssignReviewers(w http.ResponseWriter, r *http.Request) {
 	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
 	submissionID := uint(submissionID64)
 	if err != nil {
-		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
 		w.WriteHeader(http.StatusBadRequest)
 
 	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
-		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
 		w.WriteHeader(http.StatusUnauthorized)
 
 	} else if ctx.UserType != USERTYPE_EDITOR { // logged in user must be an editor to assign reviewers
-		resp = &StandardResponse{Message: "The client must have editor permissions to assign reviewers.", Error: true}
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to assign reviewers.", Error: true}
 		w.WriteHeader(http.StatusUnauthorized)
 
 	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
 		// request body could not be validated or decoded
-		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
 		w.WriteHeader(http.StatusBadRequest)
 
-	} else if err := assignReviewers(reqBody.Reviewers, submissionID); err != nil {
-		switch err.(type) {
+	} else if reqBody.DueDate != nil && !reqBody.DueDate.After(time.Now()) {
+		resp.StandardResponse = StandardResponse{Message: "The review due date must be in the future.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := assignReviewers(reqBody.Reviewers, submissionID, reqBody.Override, ctx.ID, reqBody.DueDate); err != nil {
+		switch err := err.(type) {
 		// one of the reviewers is not registered as a user, or does not have proper permissions
 		case *BadUserError, *WrongPermissionsError:
-			resp = &StandardResponse{Message: err.Error(), Error: true}
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
 			w.WriteHeader(http.StatusBadRequest)
 
-		// editors are not allowed to assign reviewers to submissions which are already accepted or rejected
-		case *SubmissionStatusFinalisedError:
-			resp = &StandardResponse{Message: err.Error(), Error: true}
+		// reviewers have conflicts of interest, which the editor did not override
+		case *ConflictOfInterestError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			resp.Conflicts = err.Conflicts
+			w.WriteHeader(http.StatusConflict)
+
+		// editors are not allowed to assign reviewers to submissions which are already accepted or rejected,
+		// or which another editor handles
+		case *SubmissionStatusFinalisedError, *NotHandlingEditorError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
 			w.WriteHeader(http.StatusUnauthorized)
 
 		default: // Unexpected error - error out as server error.
 			log.Printf("[ERROR] could not change submission status: %v\n", err)
-			resp = &StandardResponse{Message: "Internal Server Error - could not assign reviewers", Error: true}
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not assign reviewers", Error: true}
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 	}
@@ -119,6 +134,8 @@ func PostUploadReview(w http.ResponseWriter, r *http.Request) {
 			ReviewerID:  ctx.ID,
 			Approved:    reqBody.Approved,
 			Base64Value: reqBody.Base64Value,
+			Scores:      reqBody.Scores,
+			Named:       reqBody.Named,
 		}
 		// adds the review and formats response based upon error type if one occurs
 		if err := addReview(review, submissionID); err != nil {
@@ -127,9 +144,9 @@ func PostUploadReview(w http.ResponseWriter, r *http.Request) {
 				resp = &StandardResponse{Message: err.Error(), Error: true}
 				w.WriteHeader(http.StatusUnauthorized)
 
-			// each reviewer can only upload a review once for each submission,
-			// before the submission has been accepted or rejected
-			case *DuplicateReviewError, *SubmissionStatusFinalisedError:
+			// each reviewer can only upload a review once for each version of a
+			// submission, while the submission is under review
+			case *DuplicateReviewError, *SubmissionStatusFinalisedError, *SubmissionNotUnderReviewError, *BadReviewScoresError:
 				resp = &StandardResponse{Message: err.Error(), Error: true}
 				w.WriteHeader(http.StatusBadRequest)
 
@@ -148,6 +165,73 @@ func PostUploadReview(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// router function for reviewers to edit their review of a submission's
+// current version while it is under review. The previous contents are kept
+// POST /submission/{id}/review/edit
+func PostEditReview(w http.ResponseWriter, r *http.Request) {
+	reqBody := &UploadReviewBody{}
+	resp := &StandardResponse{Message: "Review edited successfully", Error: false}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if json.NewDecoder(r.Body).Decode(reqBody) != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := editReview(&Review{
+		ReviewerID:  ctx.ID,
+		Approved:    reqBody.Approved,
+		Base64Value: reqBody.Base64Value,
+		Scores:      reqBody.Scores,
+		Named:       reqBody.Named,
+	}, submissionID); err != nil {
+		resp = reviewChangeErrorResponse(w, err, "could not edit review")
+	}
+
+	// Return response body after function successful.
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for reviewers to withdraw their review of a submission's
+// current version while it is under review, after which they can review again
+// POST /submission/{id}/review/withdraw
+func PostWithdrawReview(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Review withdrawn successfully", Error: false}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := withdrawReview(ctx.ID, submissionID); err != nil {
+		resp = reviewChangeErrorResponse(w, err, "could not withdraw review")
+	}
+
+	// Return response body after function successful.
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
 // router function for updating submission status (i.e. accepting or rejecting)
 // POST /submission/{id}/approve
 func PostUpdateSubmissionStatus(w http.ResponseWriter, r *http.Request) {
@@ -179,12 +263,20 @@ func PostUpdateSubmissionStatus(w http.ResponseWriter, r *http.Request) {
 
 	} else {
 		// changes the submission status. If an error occurs responds according to the type
-		if err := updateSubmissionStatus(reqBody.Status, submissionID); err != nil {
+		if err := updateSubmissionStatus(reqBody.Status, submissionID, ctx.ID, reqBody.Justification, &reqBody.DecisionLetterBody); err != nil {
 			switch err.(type) {
-			case *MissingReviewsError, *MissingApprovalError:
+			case *NotHandlingEditorError, *MissingReviewsError, *MissingApprovalError:
 				resp = &StandardResponse{Message: err.Error(), Error: true}
 				w.WriteHeader(http.StatusUnauthorized)
 
+			case *InvalidStatusTransitionError, *MissingJustificationError:
+				resp = &StandardResponse{Message: err.Error(), Error: true}
+				w.WriteHeader(http.StatusBadRequest)
+
+			case *NoLetterTemplateError:
+				resp = &StandardResponse{Message: err.Error(), Error: true}
+				w.WriteHeader(http.StatusNotFound)
+
 			default: // Unexpected error - error out as server error.
 				log.Printf("[ERROR] could not change submission status: %v\n", err)
 				resp = &StandardResponse{Message: "Internal Server Error - could not change submission status", Error: true}
@@ -204,31 +296,63 @@ func PostUpdateSubmissionStatus(w http.ResponseWriter, r *http.Request) {
 // Helper Functions
 // ------------
 
-// assigns reviewers to a given submission
+// assigns reviewers to a given submission, checking that they have no
+// conflicts of interest with it unless the editor overrides them, and invites
+// them to review it
 //
 // Params:
 // 	reviewerIDs ([]string) : a list of reviewers IDs to be added to the submission
 // 	submissionID (uint) : the ID of the submission which reviewer IDs are to be added to
+// 	override (bool) : whether to assign reviewers despite conflicts of interest (which gets recorded)
+// 	editorID (string) : the ID of the editor assigning the reviewers
+// 	dueDate (*time.Time) : the deadline of the reviews, nil for the journal's review period
 // Returns:
-// 	(error) : an error if one occurs
-func assignReviewers(reviewerIDs []string, submissionID uint) error {
+// 	(error) : a *ConflictOfInterestError listing the conflicts if not overridden, another error if one occurs
+func assignReviewers(reviewerIDs []string, submissionID uint, override bool, editorID string, dueDate *time.Time) error {
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		return assignReviewersTx(tx, reviewerIDs, submissionID, override, editorID, dueDate)
+	}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// assigns reviewers to a given submission within a transaction (see assignReviewers)
+func assignReviewersTx(tx *gorm.DB, reviewerIDs []string, submissionID uint, override bool, editorID string, dueDate *time.Time) error {
 	// builds array of reviewers
 	reviewers := make([]GlobalUser, len(reviewerIDs))
 	for i, reviewerID := range reviewerIDs {
 		reviewers[i] = GlobalUser{ID: reviewerID}
 	}
-	// begins a transaction and checks that the submission has not been approved or disapproved yet
-	if err := gormDb.Transaction(func(tx *gorm.DB) error {
-		submission := &Submission{}
-		if err := tx.Model(&Submission{}).Select("approved").Find(&submission, submissionID).Error; err != nil {
+	// checks that the submission is still open for review, locking it until the reviewers are assigned
+	submission := &Submission{}
+	if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Model(&Submission{}).Select("id, status, approved, handling_editor_id").Find(&submission, submissionID).Error; err != nil {
+		return err
+	} else if isFinalStatus(submission.Status) || submission.Status == STATUS_DRAFT {
+		return &SubmissionStatusFinalisedError{SubmissionID: submissionID}
+	} else if err := checkHandlingEditor(tx, submission, editorID); err != nil {
+		return err
+	}
+	if conflicts, err := findConflicts(tx, submissionID, reviewerIDs); err != nil {
+		return err
+	} else if len(conflicts) > 0 && !override {
+		return &ConflictOfInterestError{SubmissionID: submissionID, Conflicts: conflicts}
+	} else if len(conflicts) > 0 {
+		if err := recordConflictOverrides(tx, submissionID, editorID, conflicts); err != nil {
 			return err
-		} else if submission.Approved != nil {
-			return &SubmissionStatusFinalisedError{SubmissionID: submissionID}
 		}
-		return addReviewers(tx, reviewers, submissionID)
-	}); err != nil {
+	}
+	dueAt := time.Time{}
+	if dueDate != nil {
+		dueAt = *dueDate
+	}
+	if err := addReviewers(tx, reviewers, submissionID, dueAt, editorID); err != nil {
 		return err
 	}
+	// assigning reviewers starts the review of a (re)submitted submission
+	if submission.Status == STATUS_SUBMITTED || submission.Status == STATUS_RESUBMITTED {
+		return setSubmissionStatus(tx, submission, STATUS_UNDER_REVIEW, editorID)
+	}
 	return nil
 }
 
@@ -245,9 +369,11 @@ func addReview(review *Review, submissionID uint) error {
 		return err
 	}
 
-	// checks that the given submission has not been approved yet (as approved submissions cannot have new reviews submitted)
-	if submission.Approved != nil && *submission.Approved == true {
+	// checks that the given submission is under review (as finalised submissions cannot have new reviews submitted)
+	if isFinalStatus(submission.Status) {
 		return &SubmissionStatusFinalisedError{SubmissionID: submissionID}
+	} else if submission.Status != STATUS_UNDER_REVIEW {
+		return &SubmissionNotUnderReviewError{SubmissionID: submissionID, Status: submission.Status}
 	}
 	// checks that the reviewer is assigned to the given submission (implicitly checks usertype)
 	isReviewer := false
@@ -260,52 +386,180 @@ func addReview(review *Review, submissionID uint) error {
 	if !isReviewer {
 		return &NotReviewerError{UserID: review.ReviewerID, SubmissionID: submissionID}
 	}
-	// checks that the reviewer has not already uploaded a review
-	for _, currReview := range submission.MetaData.Reviews {
+	// checks that the reviewer has not already uploaded a review of the current version
+	for _, currReview := range currentRoundReviews(submission) {
 		if review.ReviewerID == currReview.ReviewerID {
 			return &DuplicateReviewError{UserID: review.ReviewerID, SubmissionID: submissionID}
 		}
 	}
+	review.Round = submission.Version
+
+	// checks the review's scores against the submission's active rubric
+	rubric, err := getActiveRubric(gormDb, submission)
+	if err != nil {
+		return err
+	} else if err := validateReviewScores(rubric, review.Scores); err != nil {
+		return err
+	} else if rubric != nil {
+		review.RubricID = rubric.ID
+	}
 
 	// adds the review to the given submission
 	submission.MetaData.Reviews = append(submission.MetaData.Reviews, review)
-	return addMetaData(submission)
+	if err := addMetaData(submission); err != nil {
+		return err
+	} else if err := setReviewCompleted(gormDb, submissionID, review.ReviewerID, true); err != nil {
+		return err
+	}
+	return recordEvent(gormDb, submissionID, EVENT_REVIEW_SUBMITTED, review.ReviewerID, false,
+		map[string]string{"version": strconv.Itoa(int(review.Round))})
 }
 
-// approves or dissaproves a given submission by ID
+// edits a reviewer's review of a submission's current version, keeping its
+// previous contents in the review's revision history
 //
 // Params:
-//	status (bool) : indicates whether submission should be marked accepted or rejected
-// 	submissionID (uint) : the submissions unique ID
+// 	review (*Review) : the reviewer's ID and the new contents of the review
+// 	submissionID (uint) : the id of the submission the review belongs to
+// Return:
+// 	(error) : an error if one occurs, nil otherwise
+func editReview(review *Review, submissionID uint) error {
+	submission, err := getSubmission(submissionID)
+	if err != nil {
+		return err
+	}
+	currReview, err := getOwnReview(submission, review.ReviewerID)
+	if err != nil {
+		return err
+	}
+	// the edited review is scored against the same rubric as the original
+	rubric, err := getRubric(gormDb, currReview.RubricID)
+	if err != nil {
+		return err
+	} else if err := validateReviewScores(rubric, review.Scores); err != nil {
+		return err
+	}
+	currReview.Revisions = append(currReview.Revisions, &ReviewRevision{
+		Approved:    currReview.Approved,
+		Base64Value: currReview.Base64Value,
+		Scores:      currReview.Scores,
+		ReplacedAt:  time.Now(),
+	})
+	currReview.Approved = review.Approved
+	currReview.Base64Value = review.Base64Value
+	currReview.Scores = review.Scores
+	currReview.Named = review.Named
+	if err := addMetaData(submission); err != nil {
+		return err
+	}
+	return recordEvent(gormDb, submissionID, EVENT_REVIEW_EDITED, review.ReviewerID, false,
+		map[string]string{"version": strconv.Itoa(int(currReview.Round))})
+}
+
+// withdraws a reviewer's review of a submission's current version. The review
+// is kept for editors to inspect but is no longer counted
+//
+// Params:
+// 	reviewerID (string) : the ID of the reviewer withdrawing their review
+// 	submissionID (uint) : the id of the submission the review belongs to
 // Return:
 // 	(error) : an error if one occurs, nil otherwise
-func updateSubmissionStatus(status bool, submissionID uint) error {
+func withdrawReview(reviewerID string, submissionID uint) error {
 	submission, err := getSubmission(submissionID)
 	if err != nil {
 		return err
 	}
+	currReview, err := getOwnReview(submission, reviewerID)
+	if err != nil {
+		return err
+	}
+	withdrawnAt := time.Now()
+	currReview.WithdrawnAt = &withdrawnAt
+	if err := addMetaData(submission); err != nil {
+		return err
+	} else if err := setReviewCompleted(gormDb, submissionID, reviewerID, false); err != nil {
+		return err
+	}
+	// withdrawn reviews are only shown to editors
+	return recordEvent(gormDb, submissionID, EVENT_REVIEW_WITHDRAWN, reviewerID, true,
+		map[string]string{"version": strconv.Itoa(int(currReview.Round))})
+}
 
-	// maps reviewer ID to review approval status
-	reviews := make(map[string]bool)
-	if len(submission.Reviewers) == 0 && status { // no reviews uploaded, cannot accept submission
-		return &MissingReviewsError{SubmissionID: submissionID}
-	} 
-	for _, review := range submission.MetaData.Reviews {
-		reviews[review.ReviewerID] = review.Approved
+// gets a reviewer's review of a submission's current version, checking that
+// the submission is still under review and hence the review can be changed
+func getOwnReview(submission *Submission, reviewerID string) (*Review, error) {
+	if isFinalStatus(submission.Status) {
+		return nil, &SubmissionStatusFinalisedError{SubmissionID: submission.ID}
+	} else if submission.Status != STATUS_UNDER_REVIEW {
+		return nil, &SubmissionNotUnderReviewError{SubmissionID: submission.ID, Status: submission.Status}
 	}
-	// checks that each reviewer has submitted a review
-	for _, reviewer := range submission.Reviewers {
-		if approved, ok := reviews[reviewer.ID]; !ok && status {
-			// a review is missing -> cannot accept submission
-			return &MissingReviewsError{SubmissionID: submissionID}
-		} else if !approved && status {
-			// a reviewer does not approve, hence the editor cannot accept the submission (but can still reject)
-			return &MissingApprovalError{SubmissionID: submissionID}
+	for _, review := range currentRoundReviews(submission) {
+		if review.ReviewerID == reviewerID {
+			return review, nil
 		}
 	}
-	// updates the submission to be accepted/rejected
-	if err := gormDb.Model(&Submission{}).Where("ID = ?", submissionID).Update("approved", status).Error; err != nil {
-		return err
+	return nil, &NoReviewError{UserID: reviewerID, SubmissionID: submission.ID}
+}
+
+// hides withdrawn and archived reviews and review revisions, which only editors can inspect
+func hideReviewHistory(data *SubmissionData) {
+	if data == nil {
+		return
 	}
-	return nil
+	reviews := []*Review{}
+	for _, review := range data.Reviews {
+		if review.WithdrawnAt == nil && review.ArchivedAt == nil {
+			reviews = append(reviews, &Review{
+				ReviewerID:  review.ReviewerID,
+				Approved:    review.Approved,
+				Base64Value: review.Base64Value,
+				Round:       review.Round,
+				Named:       review.Named,
+				RubricID:    review.RubricID,
+				Scores:      review.Scores,
+			})
+		}
+	}
+	data.Reviews = reviews
+}
+
+// builds the response for a failed review edit or withdrawal, writing its status code
+func reviewChangeErrorResponse(w http.ResponseWriter, err error, action string) *StandardResponse {
+	switch err.(type) {
+	case *NoSubmissionError, *NoReviewError:
+		w.WriteHeader(http.StatusNotFound)
+	case *SubmissionStatusFinalisedError, *SubmissionNotUnderReviewError, *BadReviewScoresError:
+		w.WriteHeader(http.StatusBadRequest)
+	default: // Unexpected error - error out as server error.
+		log.Printf("[ERROR] %s: %v\n", action, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return &StandardResponse{Message: "Internal Server Error - " + action, Error: true}
+	}
+	return &StandardResponse{Message: err.Error(), Error: true}
+}
+
+// approves or dissaproves a given submission by ID
+//
+// Params:
+//	status (bool) : indicates whether submission should be marked accepted or rejected
+// 	submissionID (uint) : the submissions unique ID
+// 	editorID (string) : the editor making the decision
+// 	justification (string) : the editor's justification for the decision
+// 	letter (*DecisionLetterBody) : the editor's letter to the authors, nil for no letter
+// Return:
+// 	(error) : an error if one occurs, nil otherwise
+func updateSubmissionStatus(status bool, submissionID uint, editorID string, justification string, letter *DecisionLetterBody) error {
+	// accepting requires the reviews to meet the submission's acceptance policy
+	newStatus := STATUS_REJECTED
+	if status {
+		newStatus = STATUS_ACCEPTED
+	}
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that concurrent decisions are checked one after the other
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		}
+		return decideSubmission(tx, submission, newStatus, editorID, justification, letter)
+	})
 }
@@ -16,6 +16,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -54,7 +55,7 @@ func TestPostAssignReviewers(t *testing.T) {
 			Abstract: "Test",
 		},
 	}
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -183,6 +184,40 @@ func TestPostAssignReviewers(t *testing.T) {
 		}
 	})
 
+	t.Run("Assign Conflicted Reviewer", func(t *testing.T) {
+		// the second author and reviewer share an organization
+		conflicted := Submission{
+			Name:     "Conflicted",
+			Authors:  []GlobalUser{globalAuthors[1]},
+			MetaData: &SubmissionData{Abstract: "Test"},
+		}
+		conflictedID, err := addSubmission(&conflicted, EVENT_CREATED, "")
+		if !assert.NoError(t, err, "Submission creation shouldn't error!") {
+			return
+		}
+		ctx := &RequestContext{
+			ID:       editorID,
+			UserType: USERTYPE_EDITOR,
+		}
+		reqStruct := &AssignReviewersBody{Reviewers: []string{globalReviewers[1].ID}}
+		if !assert.Equal(t, http.StatusConflict, testAssignReviewers(reqStruct, conflictedID, ctx), "conflict not detected") {
+			return
+		}
+
+		// overriding the conflict assigns the reviewer and records the override
+		reqStruct.Override = true
+		if !assert.Equal(t, http.StatusOK, testAssignReviewers(reqStruct, conflictedID, ctx), "override did not succeed") {
+			return
+		}
+		overrides := []ConflictOverride{}
+		switch {
+		case !assert.NoError(t, gormDb.Where("submission_id = ?", conflictedID).Find(&overrides).Error, "error querying db"),
+			!assert.Len(t, overrides, 1, "override not recorded"),
+			!assert.Equal(t, editorID, overrides[0].EditorID, "overriding editor not recorded"):
+			return
+		}
+	})
+
 	// make sure this test runs last
 	t.Run("Assign Reviewer to Approved Submission", func(t *testing.T) {
 		reqStruct := &AssignReviewersBody{
@@ -193,7 +228,8 @@ func TestPostAssignReviewers(t *testing.T) {
 			UserType: USERTYPE_EDITOR,
 		}
 		// approves the submission
-		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).Update("approved", true).Error,
+		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).
+			Updates(map[string]interface{}{"approved": true, "status": STATUS_ACCEPTED}).Error,
 			"changing submission status should not err!") {
 			return
 		}
@@ -229,7 +265,7 @@ func TestUploadReview(t *testing.T) {
 		},
 	}
 
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -387,15 +423,15 @@ func TestPostUpdateSubmissionStatus(t *testing.T) {
 		},
 	}
 
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
 
-	// resets the submission status to null
+	// resets the submission status to under review
 	resetSubmissionStatus := func(submissionID uint) {
 		assert.NoError(t, gormDb.Model(&Submission{}).Where("id = ?", submissionID).
-			Update("approved", nil).Error, "submission approval not reset")
+			Updates(map[string]interface{}{"approved": nil, "status": STATUS_UNDER_REVIEW}).Error, "submission approval not reset")
 	}
 
 	// function to format and send test requests
@@ -500,7 +536,7 @@ func TestPostUpdateSubmissionStatus(t *testing.T) {
 			Authors:  []GlobalUser{globalAuthors[0]},
 			MetaData: &SubmissionData{Abstract: "Test"},
 		}
-		subID, err := addSubmission(&submission)
+		subID, err := addSubmission(&submission, EVENT_CREATED, "")
 		if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 			return
 		}
@@ -533,7 +569,7 @@ func TestAddReview(t *testing.T) {
 		},
 	}
 
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -600,7 +636,8 @@ func TestAddReview(t *testing.T) {
 	// uploading reviews to already approved submissions is not allowed behaviour
 	t.Run("Submission Approved", func(t *testing.T) {
 		// approves submission
-		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).Update("approved", true).Error,
+		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).
+			Updates(map[string]interface{}{"approved": true, "status": STATUS_ACCEPTED}).Error,
 			"submission unable to be marked approved") {
 			return
 		}
@@ -613,6 +650,113 @@ func TestAddReview(t *testing.T) {
 	})
 }
 
+func TestEditReview(t *testing.T) {
+	testInit()
+	defer testEnd()
+
+	// adds a submission to the db with authors and reviewers
+	globalAuthors, globalReviewers, err := initMockUsers(t)
+	if !assert.NoError(t, err, "could not init mock users") {
+		return
+	}
+
+	submission := Submission{
+		Name:      "Test",
+		Authors:   []GlobalUser{globalAuthors[0]},
+		Reviewers: []GlobalUser{globalReviewers[0], globalReviewers[1]},
+		MetaData: &SubmissionData{
+			Abstract: "Test",
+		},
+	}
+
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
+	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
+		return
+	}
+	review := &Review{
+		ReviewerID:  globalReviewers[0].ID,
+		Approved:    false,
+		Base64Value: "first",
+	}
+	if !assert.NoError(t, addReview(review, submissionID), "Review Addition shouldn't error!") {
+		return
+	}
+
+	t.Run("Edit review", func(t *testing.T) {
+		edited := &Review{ReviewerID: globalReviewers[0].ID, Approved: true, Base64Value: "second"}
+		if !assert.NoError(t, editReview(edited, submissionID), "Review edit shouldn't error!") {
+			return
+		}
+		queriedMetaData, err := getSubmissionMetaData(submissionID)
+		if !assert.NoError(t, err, "Error while getting submission metadata!") {
+			return
+		}
+		queriedReview := queriedMetaData.Reviews[0]
+		switch {
+		case !assert.Len(t, queriedMetaData.Reviews, 1, "Edit added a new review"),
+			!assert.Equal(t, edited.Base64Value, queriedReview.Base64Value, "Review content not edited"),
+			!assert.True(t, queriedReview.Approved, "Review approval not edited"),
+			!assert.Len(t, queriedReview.Revisions, 1, "Revision not recorded"),
+			!assert.Equal(t, review.Base64Value, queriedReview.Revisions[0].Base64Value, "Revision content does not match"):
+			return
+		}
+	})
+
+	t.Run("Edit missing review", func(t *testing.T) {
+		edited := &Review{ReviewerID: globalReviewers[1].ID, Approved: true, Base64Value: "second"}
+		assert.IsType(t, &NoReviewError{}, editReview(edited, submissionID), "Edited review which does not exist")
+	})
+
+	t.Run("Withdraw review", func(t *testing.T) {
+		if !assert.NoError(t, withdrawReview(globalReviewers[0].ID, submissionID), "Review withdrawal shouldn't error!") {
+			return
+		}
+		assert.IsType(t, &NoReviewError{}, withdrawReview(globalReviewers[0].ID, submissionID), "Withdrew review twice")
+		// the reviewer can review again once their review is withdrawn
+		if !assert.NoError(t, addReview(review, submissionID), "Review after withdrawal shouldn't error!") {
+			return
+		}
+		queriedMetaData, err := getSubmissionMetaData(submissionID)
+		if !assert.NoError(t, err, "Error while getting submission metadata!") {
+			return
+		}
+		switch {
+		case !assert.Len(t, queriedMetaData.Reviews, 2, "Withdrawn review not kept"),
+			!assert.NotNil(t, queriedMetaData.Reviews[0].WithdrawnAt, "Review not marked withdrawn"):
+			return
+		}
+	})
+
+	t.Run("Edit review of decided submission", func(t *testing.T) {
+		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).
+			Updates(map[string]interface{}{"approved": false, "status": STATUS_REJECTED}).Error,
+			"submission unable to be marked rejected") {
+			return
+		}
+		edited := &Review{ReviewerID: globalReviewers[0].ID, Approved: true, Base64Value: "third"}
+		assert.IsType(t, &SubmissionStatusFinalisedError{}, editReview(edited, submissionID), "Edited review of decided submission")
+		assert.IsType(t, &SubmissionStatusFinalisedError{}, withdrawReview(globalReviewers[0].ID, submissionID),
+			"Withdrew review of decided submission")
+	})
+}
+
+// tests that withdrawn reviews and revisions are hidden from non-editors
+func TestHideReviewHistory(t *testing.T) {
+	withdrawnAt := time.Now()
+	data := &SubmissionData{Reviews: []*Review{
+		{ReviewerID: "a", Approved: true, Base64Value: "old", WithdrawnAt: &withdrawnAt},
+		{ReviewerID: "b", Approved: true, Base64Value: "new", Revisions: []*ReviewRevision{{Base64Value: "typo"}}},
+	}}
+	hideReviewHistory(data)
+	switch {
+	case !assert.Len(t, data.Reviews, 1, "withdrawn review not hidden"),
+		!assert.Equal(t, "b", data.Reviews[0].ReviewerID, "wrong review hidden"),
+		!assert.Empty(t, data.Reviews[0].Revisions, "revisions not hidden"):
+		return
+	}
+	hideReviewHistory(nil)
+}
+
 func TestUpdateSubmissionStatus(t *testing.T) {
 	// configures main test environment
 	testInit()
@@ -633,7 +777,7 @@ func TestUpdateSubmissionStatus(t *testing.T) {
 		},
 	}
 
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -641,7 +785,8 @@ func TestUpdateSubmissionStatus(t *testing.T) {
 	// resets the submission's approval status to nil and uploads a new review with status given as a parameter
 	resetSubmission := func(approved bool) {
 		// resets the submission status and reviews
-		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).Update("approved", nil).Error,
+		if !assert.NoError(t, gormDb.Model(&Submission{}).Where("ID = ?", submissionID).
+			Updates(map[string]interface{}{"approved": nil, "status": STATUS_UNDER_REVIEW}).Error,
 			"Error while resetting submission status") {
 			return
 		}
@@ -671,13 +816,13 @@ func TestUpdateSubmissionStatus(t *testing.T) {
 
 	// runs first so that there are no uploaded reviews
 	t.Run("Update status missing reviews", func(t *testing.T) {
-		assert.Error(t, updateSubmissionStatus(true, submissionID), "no error for updating submission status of unreviewed submission")
+		assert.Error(t, updateSubmissionStatus(true, submissionID, "", "", nil), "no error for updating submission status of unreviewed submission")
 	})
 
 	t.Run("Update status valid", func(t *testing.T) {
 		t.Run("Approve Valid", func(t *testing.T) {
 			resetSubmission(true)
-			assert.NoError(t, updateSubmissionStatus(true, submissionID), "status update should not error")
+			assert.NoError(t, updateSubmissionStatus(true, submissionID, "", "", nil), "status update should not error")
 			submission := &Submission{}
 			if !assert.NoError(t, gormDb.Model(&Submission{}).Select("submissions.approved").Find(submission, submissionID).Error, "unable to find submission") {
 				return
@@ -687,13 +832,13 @@ func TestUpdateSubmissionStatus(t *testing.T) {
 
 		t.Run("Approve Invalid", func(t *testing.T) {
 			resetSubmission(false)
-			assert.Error(t, updateSubmissionStatus(true, submissionID), "should not be able to approve submission with disapproving reviews")
+			assert.Error(t, updateSubmissionStatus(true, submissionID, "", "", nil), "should not be able to approve submission with disapproving reviews")
 		})
 
 		t.Run("Disapprove", func(t *testing.T) {
 			// with approving reviews
 			// resetSubmission(true)
-			assert.NoError(t, updateSubmissionStatus(false, submissionID), "status update should not error")
+			assert.NoError(t, updateSubmissionStatus(false, submissionID, "", "", nil), "status update should not error")
 			submission := &Submission{}
 			if !assert.NoError(t, gormDb.Model(&Submission{}).Select("submissions.approved").Find(submission, submissionID).Error, "unable to find submission") {
 				return
@@ -702,7 +847,7 @@ func TestUpdateSubmissionStatus(t *testing.T) {
 
 			// with disapproving reviews
 			resetSubmission(false)
-			assert.NoError(t, updateSubmissionStatus(false, submissionID), "status update should not error")
+			assert.NoError(t, updateSubmissionStatus(false, submissionID, "", "", nil), "status update should not error")
 			submission = &Submission{}
 			if !assert.NoError(t, gormDb.Model(&Submission{}).Select("submissions.approved").Find(submission, submissionID).Error, "unable to find submission") {
 				return
@@ -0,0 +1,502 @@
+// =========================================================================
+// blind.go
+// Created: October 19, 2026
+//
+// This file takes care of blind review: hiding reviewers' identities from
+// authors (single-blind) and authors' identities from reviewers as well
+// (double-blind) wherever a submission, its files or a profile are shown
+// =========================================================================
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_REVIEW_MODE = "/reviewmode"
+
+	REDACTED_NAME = "[redacted]"
+)
+
+// comment markers starting the lines of a file header
+var headerCommentPrefixes = []string{"//", "#", "/*", "*", "--", ";", "%", "<!--", "\"\"\"", "'''"}
+
+// markers closing the block comments a file header can be made of, by opening marker
+var blockCommentClosings = map[string]string{"/*": "*/", "<!--": "-->", "\"\"\"": "\"\"\"", "'''": "'''"}
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to set the review mode of a single submission,
+// overriding the journal's. An empty mode goes back to the journal's
+// POST /submission/{id}/reviewmode
+func PostChangeReviewMode(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Review mode updated successfully", Error: false}
+	reqBody := &ChangeReviewModeBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to change a submission's review mode.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerChangeReviewMode(reqBody.ReviewMode, submissionID, ctx.ID); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not change review mode: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not change review mode", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which sets the review mode of a submission.
+//
+// Params:
+// 	mode (string) : the submission's review mode, empty to use the journal's
+// 	submissionID (uint) : the submission to update
+// 	editorID (string) : the editor changing the review mode
+// Returns:
+// 	(error) : an error if one occurs
+func ControllerChangeReviewMode(mode string, submissionID uint, editorID string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		if res := tx.Select("id").Limit(1).Find(&Submission{}, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+		if err := tx.Model(&Submission{}).Where("id = ?", submissionID).Update("review_mode", mode).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, submissionID, EVENT_EDITED, editorID, true, map[string]string{"reviewMode": mode})
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the review mode applying to a submission: its own if set, else the journal's
+func getReviewMode(tx *gorm.DB, submission *Submission) (string, error) {
+	if submission.ReviewMode != "" {
+		return submission.ReviewMode, nil
+	}
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return "", err
+	}
+	return settings.ReviewMode, nil
+}
+
+// Gets the aliases replacing the identities a user cannot see on a
+// submission (see getBlindAliases), numbering every reviewer ever invited to
+// review it, so that reviewers who were removed or did not take the
+// invitation stay hidden and keep their aliases.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submission (*Submission) : the submission, with its authors and reviewers set
+// 	mode (string) : the review mode applying to the submission
+// 	ctx (*RequestContext) : the logged in user, nil if no user is logged in
+// Returns:
+// 	(map[string]string) : aliases (i.e. "Reviewer 1") by hidden user ID, nil if nothing is hidden
+// 	(error) : an error if one occurs
+func getSubmissionAliases(tx *gorm.DB, submission *Submission, mode string, ctx *RequestContext) (map[string]string, error) {
+	invited, err := getInvitedReviewers(tx, submission.ID)
+	if err != nil {
+		return nil, err
+	}
+	return getBlindAliases(submission, invited, mode, ctx), nil
+}
+
+// gets the reviewers ever invited to review a submission, in the order they were invited
+func getInvitedReviewers(tx *gorm.DB, submissionID uint) ([]string, error) {
+	invited := []string{}
+	if err := tx.Unscoped().Model(&ReviewInvitation{}).Where("submission_id = ?", submissionID).
+		Order("created_at, id").Pluck("reviewer_id", &invited).Error; err != nil {
+		return nil, err
+	}
+	return invited, nil
+}
+
+// Gets the aliases replacing the identities a user cannot see on a
+// submission. Reviewers are hidden from everyone but editors and the
+// submission's reviewers in blind modes, and authors are hidden from the
+// submission's reviewers in double-blind mode.
+//
+// Params:
+// 	submission (*Submission) : the submission, with its authors and reviewers set
+// 	invited ([]string) : the reviewers ever invited to review the submission, in the order they were invited
+// 	mode (string) : the review mode applying to the submission
+// 	ctx (*RequestContext) : the logged in user, nil if no user is logged in
+// Returns:
+// 	(map[string]string) : aliases (i.e. "Reviewer 1") by hidden user ID, nil if nothing is hidden
+func getBlindAliases(submission *Submission, invited []string, mode string, ctx *RequestContext) map[string]string {
+	if mode != REVIEW_MODE_SINGLE_BLIND && mode != REVIEW_MODE_DOUBLE_BLIND {
+		return nil
+	} else if ctx != nil && ctx.UserType == USERTYPE_EDITOR {
+		return nil
+	}
+	isReviewer := ctx != nil && isUserInList(ctx.ID, submission.Reviewers) && !isUserInList(ctx.ID, submission.Authors)
+
+	aliases := make(map[string]string)
+	if !isReviewer {
+		addReviewerAliases(aliases, invited, submission.Reviewers)
+	} else if mode == REVIEW_MODE_DOUBLE_BLIND {
+		addAliases(aliases, submission.Authors, "Author")
+	}
+	return aliases
+}
+
+// gives users numbered aliases, ordered by ID so that they are stable across requests
+func addAliases(aliases map[string]string, users []GlobalUser, role string) {
+	ids := []string{}
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	sort.Strings(ids)
+	for i, id := range ids {
+		aliases[id] = fmt.Sprintf("%s %d", role, i+1)
+	}
+}
+
+// gives reviewers numbered aliases in the order they were invited, so that
+// removing a reviewer does not renumber the others. Reviewers assigned
+// without an invitation come last, ordered by ID
+func addReviewerAliases(aliases map[string]string, invited []string, reviewers []GlobalUser) {
+	count := 0
+	for _, id := range invited {
+		if _, ok := aliases[id]; !ok {
+			count++
+			aliases[id] = fmt.Sprintf("Reviewer %d", count)
+		}
+	}
+	ids := []string{}
+	for _, reviewer := range reviewers {
+		if _, ok := aliases[reviewer.ID]; !ok {
+			ids = append(ids, reviewer.ID)
+		}
+	}
+	sort.Strings(ids)
+	for i, id := range ids {
+		aliases[id] = fmt.Sprintf("Reviewer %d", count+i+1)
+	}
+}
+
+// hides the identities the aliases are given for from a submission's user
+// lists and replaces them in its reviews
+func blindSubmission(submission *Submission, aliases map[string]string) {
+	if len(aliases) == 0 {
+		return
+	}
+	removeAliased := func(users []GlobalUser) []GlobalUser {
+		visible := []GlobalUser{}
+		for _, user := range users {
+			if _, ok := aliases[user.ID]; !ok {
+				visible = append(visible, user)
+			}
+		}
+		return visible
+	}
+	submission.Authors = removeAliased(submission.Authors)
+	submission.Reviewers = removeAliased(submission.Reviewers)
+	if submission.MetaData != nil {
+		for _, review := range submission.MetaData.Reviews {
+			if alias, ok := aliases[review.ReviewerID]; ok {
+				review.ReviewerID = alias
+			}
+		}
+	}
+}
+
+// replaces the authors of comments and their replies by their aliases, as
+// well as the users they mention, in their mentions and bodies
+func blindComments(comments []Comment, aliases map[string]string) {
+	for i := range comments {
+		if alias, ok := aliases[comments[i].AuthorID]; ok {
+			comments[i].AuthorID = alias
+		}
+		if comments[i].ResolvedBy != nil {
+			if alias, ok := aliases[*comments[i].ResolvedBy]; ok {
+				comments[i].ResolvedBy = &alias
+			}
+		}
+		for j := range comments[i].Reactions {
+			if alias, ok := aliases[comments[i].Reactions[j].UserID]; ok {
+				comments[i].Reactions[j].UserID = alias
+			}
+		}
+		for j := range comments[i].Mentions {
+			if alias, ok := aliases[comments[i].Mentions[j].UserID]; ok {
+				comments[i].Base64Value = replaceCommentText(comments[i].Base64Value, comments[i].Mentions[j].Text, "@"+alias)
+				comments[i].Mentions[j].UserID = alias
+				comments[i].Mentions[j].Text = "@" + alias
+			}
+		}
+		blindComments(comments[i].Comments, aliases)
+	}
+}
+
+// Hides the identities a user cannot see from a file's comments, and redacts
+// author names from the file's header for reviewers in double-blind review if
+// the journal asks for it.
+//
+// Params:
+// 	file (*File) : the file, with its content and comments set
+// 	ctx (*RequestContext) : the logged in user, nil if no user is logged in
+// Returns:
+// 	(error) : an error if one occurs
+func blindFile(file *File, ctx *RequestContext) error {
+	submission := &Submission{}
+	if err := gormDb.Preload("Authors").Preload("Reviewers").Find(submission, file.SubmissionID).Error; err != nil {
+		return err
+	}
+	settings, err := getJournalSettings(gormDb)
+	if err != nil {
+		return err
+	}
+	mode := submission.ReviewMode
+	if mode == "" {
+		mode = settings.ReviewMode
+	}
+	aliases, err := getSubmissionAliases(gormDb, submission, mode, ctx)
+	if err != nil {
+		return err
+	}
+	blindComments(file.Comments, aliases)
+	if areAuthorsHidden(submission, mode, ctx) && settings.RedactAuthorNames {
+		if content, err := base64.StdEncoding.DecodeString(file.Base64Value); err == nil {
+			file.Base64Value = base64.StdEncoding.EncodeToString([]byte(redactAuthorNames(string(content), submission.Authors)))
+		} else {
+			file.Base64Value = redactAuthorNames(file.Base64Value, submission.Authors)
+		}
+	}
+	return nil
+}
+
+// Redacts the names of authors from the header of a file, i.e. the comment
+// lines it starts with.
+//
+// Params:
+// 	content (string) : the file's content
+// 	authors ([]GlobalUser) : the authors whose names are redacted
+// Returns:
+// 	(string) : the file's content with names in its header redacted
+func redactAuthorNames(content string, authors []GlobalUser) string {
+	// matches full names first, so that they are redacted as a whole
+	names := []string{}
+	for _, author := range authors {
+		names = append(names, regexp.QuoteMeta(strings.TrimSpace(author.FirstName+" "+author.LastName)))
+	}
+	for _, author := range authors {
+		for _, name := range []string{author.FirstName, author.LastName} {
+			if len(strings.TrimSpace(name)) > 1 {
+				names = append(names, regexp.QuoteMeta(strings.TrimSpace(name)))
+			}
+		}
+	}
+	if len(names) == 0 {
+		return content
+	}
+	namePattern := regexp.MustCompile(`(?i)\b(` + strings.Join(names, "|") + `)\b`)
+
+	lines := strings.SplitAfter(content, "\n")
+	closing := "" // closing marker of the block comment the header is in, if any
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if closing == "" && trimmed != "" && !isHeaderCommentLine(trimmed) {
+			break
+		}
+		lines[i] = namePattern.ReplaceAllString(line, REDACTED_NAME)
+		if closing != "" {
+			if strings.Contains(trimmed, closing) {
+				closing = ""
+			}
+		} else {
+			for opening, blockClosing := range blockCommentClosings {
+				if strings.HasPrefix(trimmed, opening) && !strings.Contains(trimmed[len(opening):], blockClosing) {
+					closing = blockClosing
+				}
+			}
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+// checks whether a (trimmed) line is a comment line of a file header
+func isHeaderCommentLine(line string) bool {
+	if strings.HasPrefix(line, "#!") {
+		return true
+	}
+	for _, prefix := range headerCommentPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// checks whether a submission's authors are hidden from a user, which they
+// only are from its reviewers in double-blind review
+func areAuthorsHidden(submission *Submission, mode string, ctx *RequestContext) bool {
+	aliases := getBlindAliases(submission, nil, mode, ctx)
+	for _, author := range submission.Authors {
+		if _, ok := aliases[author.ID]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Filters out the submissions whose authors are hidden from a user, so that
+// profiles and author searches do not reveal who wrote the submissions they
+// review under double-blind review.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submissions ([]Submission) : the submissions, with their IDs set
+// 	ctx (*RequestContext) : the logged in user, nil if no user is logged in
+// Returns:
+// 	([]Submission) : the submissions whose authors the user can see
+// 	(error) : an error if one occurs
+func filterBlindAuthoredSubmissions(tx *gorm.DB, submissions []Submission, ctx *RequestContext) ([]Submission, error) {
+	if len(submissions) == 0 || ctx == nil || ctx.UserType == USERTYPE_EDITOR {
+		return submissions, nil
+	}
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return nil, err
+	}
+	ids := []uint{}
+	for _, submission := range submissions {
+		ids = append(ids, submission.ID)
+	}
+	participants := []Submission{}
+	if err := tx.Preload("Authors").Preload("Reviewers").Select("id, review_mode").
+		Find(&participants, ids).Error; err != nil {
+		return nil, err
+	}
+	visibleIDs := make(map[uint]bool)
+	for _, submission := range filterHiddenAuthors(participants, settings.ReviewMode, ctx) {
+		visibleIDs[submission.ID] = true
+	}
+	visible := []Submission{}
+	for _, submission := range submissions {
+		if visibleIDs[submission.ID] {
+			visible = append(visible, submission)
+		}
+	}
+	return visible, nil
+}
+
+// filters out the submissions, with their authors and reviewers set, whose authors are hidden from a user
+func filterHiddenAuthors(submissions []Submission, journalMode string, ctx *RequestContext) []Submission {
+	visible := []Submission{}
+	for i := range submissions {
+		mode := submissions[i].ReviewMode
+		if mode == "" {
+			mode = journalMode
+		}
+		if !areAuthorsHidden(&submissions[i], mode, ctx) {
+			visible = append(visible, submissions[i])
+		}
+	}
+	return visible
+}
+
+// Redacts the names of authors from the headers of the files of a zip
+// archive. Files which are not text are left as they are.
+//
+// Params:
+// 	zipContent ([]byte) : the zip archive
+// 	authors ([]GlobalUser) : the authors whose names are redacted
+// Returns:
+// 	([]byte) : the zip archive with names in its files' headers redacted
+// 	(error) : an error if one occurs
+func redactZipAuthorNames(zipContent []byte, authors []GlobalUser) ([]byte, error) {
+	reader, err := zip.NewReader(bytes.NewReader(zipContent), int64(len(zipContent)))
+	if err != nil {
+		return nil, err
+	}
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if utf8.Valid(content) {
+			content = []byte(redactAuthorNames(string(content), authors))
+		}
+		entry, err := writer.CreateHeader(&zip.FileHeader{Name: file.Name, Method: file.Method, Modified: file.Modified})
+		if err != nil {
+			return nil, err
+		} else if _, err := entry.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// filters out the submissions a user reviewed under blind review, for profiles
+// seen by anyone but editors and the user themselves
+func filterBlindReviewedSubmissions(submissions []Submission, journalMode string) []Submission {
+	visible := []Submission{}
+	for _, submission := range submissions {
+		mode := submission.ReviewMode
+		if mode == "" {
+			mode = journalMode
+		}
+		if mode != REVIEW_MODE_SINGLE_BLIND && mode != REVIEW_MODE_DOUBLE_BLIND {
+			visible = append(visible, submission)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,186 @@
+// =====================================
+// blind_test.go
+// Created: October 19, 2026
+//
+// test file for blind.go
+// =====================================
+
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_HEADER_CODE = `/*
+ * stack.c - written by Jane Doe
+ * Copyright 2026 DOE
+ */
+int x;
+// maintained by Jane
+int main() { printf("Jane Doe"); }
+`
+	TEST_REDACTED_HEADER_CODE = `/*
+ * stack.c - written by [redacted]
+ * Copyright 2026 [redacted]
+ */
+int x;
+// maintained by Jane
+int main() { printf("Jane Doe"); }
+`
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests which identities are hidden from which users
+func TestGetBlindAliases(t *testing.T) {
+	submission := &Submission{
+		Authors:   []GlobalUser{{ID: "author"}},
+		Reviewers: []GlobalUser{{ID: "reviewer2"}, {ID: "reviewer1"}},
+	}
+	author := &RequestContext{ID: "author", UserType: USERTYPE_PUBLISHER}
+	reviewer := &RequestContext{ID: "reviewer1", UserType: USERTYPE_REVIEWER}
+	editor := &RequestContext{ID: "editor", UserType: USERTYPE_EDITOR}
+
+	assert.Empty(t, getBlindAliases(submission, nil, REVIEW_MODE_OPEN, author), "identities hidden in open review")
+	assert.Empty(t, getBlindAliases(submission, nil, REVIEW_MODE_DOUBLE_BLIND, editor), "identities hidden from editor")
+	assert.Equal(t, map[string]string{"reviewer1": "Reviewer 1", "reviewer2": "Reviewer 2"},
+		getBlindAliases(submission, nil, REVIEW_MODE_SINGLE_BLIND, author), "reviewers not hidden from author")
+	assert.Len(t, getBlindAliases(submission, nil, REVIEW_MODE_SINGLE_BLIND, nil), 2, "reviewers not hidden from public")
+	assert.Empty(t, getBlindAliases(submission, nil, REVIEW_MODE_SINGLE_BLIND, reviewer), "identities hidden from reviewer in single-blind")
+	assert.Equal(t, map[string]string{"author": "Author 1"},
+		getBlindAliases(submission, nil, REVIEW_MODE_DOUBLE_BLIND, reviewer), "authors not hidden from reviewer")
+
+	// reviewers are numbered in the order they were invited, including those no longer assigned
+	invited := []string{"reviewer2", "removed", "reviewer2", "reviewer1"}
+	assert.Equal(t, map[string]string{"reviewer2": "Reviewer 1", "removed": "Reviewer 2", "reviewer1": "Reviewer 3"},
+		getBlindAliases(submission, invited, REVIEW_MODE_SINGLE_BLIND, author), "reviewers not aliased by invitation")
+	assert.Equal(t, map[string]string{"removed": "Reviewer 1", "reviewer1": "Reviewer 2", "reviewer2": "Reviewer 3"},
+		getBlindAliases(submission, []string{"removed"}, REVIEW_MODE_SINGLE_BLIND, author), "uninvited reviewers not aliased last")
+}
+
+// tests that hidden identities are removed from submissions and their reviews
+func TestBlindSubmission(t *testing.T) {
+	submission := &Submission{
+		Authors:   []GlobalUser{{ID: "author"}},
+		Reviewers: []GlobalUser{{ID: "reviewer"}},
+		MetaData:  &SubmissionData{Reviews: []*Review{{ReviewerID: "reviewer"}}},
+	}
+	blindSubmission(submission, map[string]string{"reviewer": "Reviewer 1"})
+	switch {
+	case !assert.Empty(t, submission.Reviewers, "reviewer not hidden"),
+		!assert.Len(t, submission.Authors, 1, "author hidden"),
+		!assert.Equal(t, "Reviewer 1", submission.MetaData.Reviews[0].ReviewerID, "review not aliased"):
+		return
+	}
+
+	comments := []Comment{{AuthorID: "reviewer", Comments: []Comment{{AuthorID: "reviewer"}, {AuthorID: "author"}}}}
+	blindComments(comments, map[string]string{"reviewer": "Reviewer 1"})
+	switch {
+	case !assert.Equal(t, "Reviewer 1", comments[0].AuthorID, "comment not aliased"),
+		!assert.Equal(t, "Reviewer 1", comments[0].Comments[0].AuthorID, "reply not aliased"),
+		!assert.Equal(t, "author", comments[0].Comments[1].AuthorID, "visible author aliased"):
+		return
+	}
+
+	// mentions of hidden users are aliased in the comment's body as well
+	body := base64.StdEncoding.EncodeToString([]byte("@reviewer and @author, see line 3"))
+	comments = []Comment{{AuthorID: "author", Base64Value: body,
+		Mentions: []CommentMention{{UserID: "reviewer", Text: "@reviewer"}, {UserID: "author", Text: "@author"}}}}
+	blindComments(comments, map[string]string{"reviewer": "Reviewer 1"})
+	switch {
+	case !assert.Equal(t, CommentMention{UserID: "Reviewer 1", Text: "@Reviewer 1"}, comments[0].Mentions[0], "mention not aliased"),
+		!assert.Equal(t, CommentMention{UserID: "author", Text: "@author"}, comments[0].Mentions[1], "visible mention aliased"),
+		!assert.Equal(t, "@Reviewer 1 and @author, see line 3", commentText(comments[0].Base64Value), "mention not aliased in body"):
+		return
+	}
+}
+
+// tests that author names are only redacted from file headers
+func TestRedactAuthorNames(t *testing.T) {
+	authors := []GlobalUser{{FirstName: "Jane", LastName: "Doe"}}
+	assert.Equal(t, TEST_REDACTED_HEADER_CODE, redactAuthorNames(TEST_HEADER_CODE, authors), "wrong names redacted")
+	assert.Equal(t, "# by [redacted]\nx = 1\n", redactAuthorNames("# by jane doe\nx = 1\n", authors), "names not redacted")
+	assert.Equal(t, "x = 'Doe'\n", redactAuthorNames("x = 'Doe'\n", authors), "code redacted")
+}
+
+// tests that submissions reviewed under blind review are hidden from profiles
+func TestFilterBlindReviewedSubmissions(t *testing.T) {
+	submissions := []Submission{
+		{Name: "default"},
+		{Name: "open", ReviewMode: REVIEW_MODE_OPEN},
+		{Name: "blind", ReviewMode: REVIEW_MODE_SINGLE_BLIND},
+	}
+	assert.Len(t, filterBlindReviewedSubmissions(submissions, REVIEW_MODE_OPEN), 2, "wrong submissions shown in open journal")
+	visible := filterBlindReviewedSubmissions(submissions, REVIEW_MODE_DOUBLE_BLIND)
+	if assert.Len(t, visible, 1, "wrong submissions shown in blind journal") {
+		assert.Equal(t, "open", visible[0].Name, "wrong submission shown")
+	}
+}
+
+// tests that reviewers cannot find out who wrote the submissions they review under double-blind review
+func TestFilterHiddenAuthors(t *testing.T) {
+	submissions := []Submission{
+		{Name: "blind", Authors: []GlobalUser{{ID: "author"}}, Reviewers: []GlobalUser{{ID: "reviewer"}}},
+		{Name: "open", ReviewMode: REVIEW_MODE_OPEN, Authors: []GlobalUser{{ID: "author"}}, Reviewers: []GlobalUser{{ID: "reviewer"}}},
+		{Name: "unreviewed", Authors: []GlobalUser{{ID: "author"}}, Reviewers: []GlobalUser{{ID: "other"}}},
+	}
+	reviewer := &RequestContext{ID: "reviewer", UserType: USERTYPE_REVIEWER}
+	editor := &RequestContext{ID: "editor", UserType: USERTYPE_EDITOR}
+
+	assert.Len(t, filterHiddenAuthors(submissions, REVIEW_MODE_SINGLE_BLIND, reviewer), 3, "authors hidden in single-blind review")
+	assert.Len(t, filterHiddenAuthors(submissions, REVIEW_MODE_DOUBLE_BLIND, editor), 3, "authors hidden from editor")
+	visible := filterHiddenAuthors(submissions, REVIEW_MODE_DOUBLE_BLIND, reviewer)
+	if assert.Len(t, visible, 2, "reviewed submission's authors shown to reviewer") {
+		assert.Equal(t, "open", visible[0].Name, "wrong submission shown")
+		assert.Equal(t, "unreviewed", visible[1].Name, "wrong submission shown")
+	}
+}
+
+// tests that author names are redacted from the headers of a downloaded archive's files
+func TestRedactZipAuthorNames(t *testing.T) {
+	// builds an archive with a source file and a binary file
+	binary := []byte{0xff, 0xfe, 'J', 'a', 'n', 'e'}
+	buf := new(bytes.Buffer)
+	writer := zip.NewWriter(buf)
+	for name, content := range map[string][]byte{"stack.c": []byte(TEST_HEADER_CODE), "data.bin": binary} {
+		entry, err := writer.Create(name)
+		if !assert.NoError(t, err, "could not create test archive") {
+			return
+		}
+		entry.Write(content)
+	}
+	if !assert.NoError(t, writer.Close(), "could not create test archive") {
+		return
+	}
+
+	redacted, err := redactZipAuthorNames(buf.Bytes(), []GlobalUser{{FirstName: "Jane", LastName: "Doe"}})
+	if !assert.NoError(t, err, "could not redact archive") {
+		return
+	}
+	reader, err := zip.NewReader(bytes.NewReader(redacted), int64(len(redacted)))
+	if !assert.NoError(t, err, "redacted archive not readable") || !assert.Len(t, reader.File, 2, "files lost") {
+		return
+	}
+	for _, file := range reader.File {
+		rc, err := file.Open()
+		if !assert.NoError(t, err, "file not readable") {
+			return
+		}
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		if file.Name == "stack.c" {
+			assert.Equal(t, TEST_REDACTED_HEADER_CODE, string(content), "names not redacted")
+		} else {
+			assert.Equal(t, binary, content, "binary file changed")
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// =========================================================================
+// classification.go
+// Created: October 19, 2026
+//
+// This file takes care of classifying uploaded files (text, binary,
+// executable, archive) and enforcing the journal's policy on them
+// =========================================================================
+
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// file classifications, recorded on the file row
+	FILE_CLASS_TEXT       = "text"
+	FILE_CLASS_BINARY     = "binary"
+	FILE_CLASS_EXECUTABLE = "executable"
+	FILE_CLASS_ARCHIVE    = "archive"
+
+	// policies applied to files depending on their classification
+	FILE_POLICY_ALLOW      = "allow"      // stored and shown like any other file
+	FILE_POLICY_ATTACHMENT = "attachment" // stored but not shown in the code viewer
+	FILE_POLICY_REJECT     = "reject"     // the whole submission is rejected
+
+	CLASSIFICATION_SAMPLE_SIZE = 8000 // number of bytes inspected to tell text from binary
+	MAX_CONTROL_CHAR_RATIO     = 0.1  // ratio of control characters above which a file is binary
+
+	PE_HEADER_OFFSET    = 0x3C // offset of the PE header's offset in an MZ header
+	MAX_FAT_MACHO_ARCHS = 20   // Java class files share the fat Mach-O magic number, with a version above this instead
+)
+
+// magic numbers of native executables (ELF and Mach-O). PE and fat Mach-O
+// executables are told apart from text and Java class files in isExecutable
+var executableMagics = [][]byte{
+	{0x7F, 'E', 'L', 'F'},
+	{0xFE, 0xED, 0xFA, 0xCE}, {0xFE, 0xED, 0xFA, 0xCF},
+	{0xCE, 0xFA, 0xED, 0xFE}, {0xCF, 0xFA, 0xED, 0xFE},
+}
+
+// magic numbers of archives and compressed files (zip, gzip, bzip2, xz, 7z, rar, zstd)
+var archiveMagics = [][]byte{
+	{'P', 'K', 0x03, 0x04}, {'P', 'K', 0x05, 0x06},
+	{0x1F, 0x8B},
+	{'B', 'Z', 'h'},
+	{0xFD, '7', 'z', 'X', 'Z', 0x00},
+	{'7', 'z', 0xBC, 0xAF, 0x27, 0x1C},
+	{'R', 'a', 'r', '!', 0x1A, 0x07},
+	{0x28, 0xB5, 0x2F, 0xFD},
+}
+
+// Classifies the files of a submission and applies the journal's file policy
+// to them. The classification, size and attachment flag are set on each file
+// so they get stored along with it.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction the files are being added in
+// 	files ([]File) : the files to classify, with content set as base64
+// Returns:
+// 	(error) : a *RejectedFileError for the first file the policy rejects
+func applyFilePolicy(tx *gorm.DB, files []File) error {
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return err
+	}
+	for i := range files {
+		content := decodeFileContent(files[i].Base64Value)
+		files[i].Classification = classifyFileContent(content)
+		files[i].Size = int64(len(content))
+
+		// the strictest of the applicable policies wins
+		policy := settings.filePolicy(files[i].Classification)
+		if settings.MaxFileSize > 0 && files[i].Size > settings.MaxFileSize {
+			policy = strictestFilePolicy(policy, settings.OversizedFilePolicy)
+		}
+		switch policy {
+		case FILE_POLICY_REJECT:
+			return &RejectedFileError{Path: files[i].Path, Classification: files[i].Classification, Size: files[i].Size}
+		case FILE_POLICY_ATTACHMENT:
+			files[i].Attachment = true
+		}
+	}
+	return nil
+}
+
+// gets the journal's policy for a given file classification
+func (s *JournalSettings) filePolicy(classification string) string {
+	switch classification {
+	case FILE_CLASS_EXECUTABLE:
+		return s.ExecutableFilePolicy
+	case FILE_CLASS_ARCHIVE:
+		return s.ArchiveFilePolicy
+	case FILE_CLASS_BINARY:
+		return s.BinaryFilePolicy
+	}
+	return FILE_POLICY_ALLOW
+}
+
+// gets the most restrictive of two file policies
+func strictestFilePolicy(a string, b string) string {
+	rank := map[string]int{FILE_POLICY_ALLOW: 0, FILE_POLICY_ATTACHMENT: 1, FILE_POLICY_REJECT: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
+
+// Classifies a file from its decoded content using magic numbers, then the
+// proportion of control characters for files with no known signature.
+//
+// Params:
+// 	content ([]byte) : the raw file content
+// Returns:
+// 	(string) : one of the FILE_CLASS_* constants
+func classifyFileContent(content []byte) string {
+	if isExecutable(content) {
+		return FILE_CLASS_EXECUTABLE
+	}
+	for _, magic := range archiveMagics {
+		if bytes.HasPrefix(content, magic) {
+			return FILE_CLASS_ARCHIVE
+		}
+	}
+	// tar archives have their magic number after the first header field
+	if len(content) > 262 && bytes.Equal(content[257:262], []byte("ustar")) {
+		return FILE_CLASS_ARCHIVE
+	}
+
+	sample := content
+	if len(sample) > CLASSIFICATION_SAMPLE_SIZE {
+		sample = sample[:CLASSIFICATION_SAMPLE_SIZE]
+	}
+	if bytes.IndexByte(sample, 0) != -1 {
+		return FILE_CLASS_BINARY
+	}
+	controlChars := 0
+	for _, b := range sample {
+		if b < 0x20 && b != '\n' && b != '\r' && b != '\t' && b != '\f' && b != '\b' && b != 0x1B {
+			controlChars++
+		}
+	}
+	if len(sample) > 0 && float64(controlChars)/float64(len(sample)) > MAX_CONTROL_CHAR_RATIO {
+		return FILE_CLASS_BINARY
+	}
+	return FILE_CLASS_TEXT
+}
+
+// Checks whether a file is a native executable. PE executables start with an
+// MZ header pointing to a "PE\0\0" signature, and fat Mach-O executables
+// start with a small architecture count where Java class files have their version.
+//
+// Params:
+// 	content ([]byte) : the raw file content
+// Returns:
+// 	(bool) : true if the file is a native executable
+func isExecutable(content []byte) bool {
+	for _, magic := range executableMagics {
+		if bytes.HasPrefix(content, magic) {
+			return true
+		}
+	}
+	if bytes.HasPrefix(content, []byte{'M', 'Z'}) && len(content) >= PE_HEADER_OFFSET+4 {
+		peOffset := int64(binary.LittleEndian.Uint32(content[PE_HEADER_OFFSET:]))
+		return peOffset+4 <= int64(len(content)) && bytes.Equal(content[peOffset:peOffset+4], []byte{'P', 'E', 0, 0})
+	}
+	if bytes.HasPrefix(content, []byte{0xCA, 0xFE, 0xBA, 0xBE}) && len(content) >= 8 {
+		archs := binary.BigEndian.Uint32(content[4:])
+		return archs > 0 && archs < MAX_FAT_MACHO_ARCHS
+	}
+	return false
+}
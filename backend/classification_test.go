@@ -0,0 +1,108 @@
+// =====================================
+// classification_test.go
+// Created: October 19, 2026
+//
+// test file for classification.go
+// =====================================
+
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests classification of file content by magic number and content
+func TestClassifyFileContent(t *testing.T) {
+	tarHeader := make([]byte, 512)
+	copy(tarHeader[257:], "ustar")
+	peHeader := make([]byte, 0x48)
+	copy(peHeader, "MZ")
+	peHeader[PE_HEADER_OFFSET] = 0x40
+	copy(peHeader[0x40:], "PE\x00\x00")
+
+	testCases := map[string]struct {
+		content        []byte
+		classification string
+	}{
+		"Source Code":   {[]byte("package main\n\nfunc main() {}\n"), FILE_CLASS_TEXT},
+		"Empty File":    {[]byte{}, FILE_CLASS_TEXT},
+		"UTF-8 Text":    {[]byte("héllo wörld\n"), FILE_CLASS_TEXT},
+		"ELF":           {append([]byte{0x7F, 'E', 'L', 'F', 2, 1, 1}, make([]byte, 32)...), FILE_CLASS_EXECUTABLE},
+		"PE":            {peHeader, FILE_CLASS_EXECUTABLE},
+		"MZ Text":       {[]byte("MZ-2 protocol notes\n" + strings.Repeat("x", 100)), FILE_CLASS_TEXT},
+		"Mach-O":        {[]byte{0xCF, 0xFA, 0xED, 0xFE, 7, 0, 0, 1}, FILE_CLASS_EXECUTABLE},
+		"Fat Mach-O":    {[]byte{0xCA, 0xFE, 0xBA, 0xBE, 0, 0, 0, 2}, FILE_CLASS_EXECUTABLE},
+		"Java Class":    {[]byte{0xCA, 0xFE, 0xBA, 0xBE, 0, 0, 0, 52}, FILE_CLASS_BINARY},
+		"Zip":           {[]byte("PK\x03\x04\x14\x00\x00\x00"), FILE_CLASS_ARCHIVE},
+		"Gzip":          {[]byte{0x1F, 0x8B, 0x08, 0x00}, FILE_CLASS_ARCHIVE},
+		"Tar":           {tarHeader, FILE_CLASS_ARCHIVE},
+		"Model Weights": {bytes.Repeat([]byte{0x00, 0x3F, 0x80, 0x01}, 100), FILE_CLASS_BINARY},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.classification, classifyFileContent(testCase.content), "file misclassified")
+		})
+	}
+}
+
+// tests that the strictest policy is picked when several apply
+func TestStrictestFilePolicy(t *testing.T) {
+	assert.Equal(t, FILE_POLICY_REJECT, strictestFilePolicy(FILE_POLICY_ATTACHMENT, FILE_POLICY_REJECT))
+	assert.Equal(t, FILE_POLICY_ATTACHMENT, strictestFilePolicy(FILE_POLICY_ATTACHMENT, FILE_POLICY_ALLOW))
+	assert.Equal(t, FILE_POLICY_ALLOW, strictestFilePolicy(FILE_POLICY_ALLOW, FILE_POLICY_ALLOW))
+}
+
+// tests that the journal's file policy is applied when adding files
+func TestApplyFilePolicy(t *testing.T) {
+	testInit()
+	defer testEnd()
+
+	encode := func(content []byte) string {
+		return base64.StdEncoding.EncodeToString(content)
+	}
+
+	t.Run("Default Policy", func(t *testing.T) {
+		files := []File{
+			{Path: "main.c", Base64Value: encode([]byte("int main() { return 0; }"))},
+			{Path: "weights.bin", Base64Value: encode([]byte{0x00, 0x01, 0x02, 0x03})},
+			{Path: "data.zip", Base64Value: encode([]byte("PK\x03\x04"))},
+		}
+		if !assert.NoError(t, applyFilePolicy(gormDb, files), "allowed files rejected") {
+			return
+		}
+		switch {
+		case !assert.Equal(t, FILE_CLASS_TEXT, files[0].Classification),
+			!assert.False(t, files[0].Attachment, "text file marked as attachment"),
+			!assert.Equal(t, int64(24), files[0].Size, "wrong file size recorded"),
+			!assert.True(t, files[1].Attachment, "binary file not marked as attachment"),
+			!assert.True(t, files[2].Attachment, "archive not marked as attachment"):
+			return
+		}
+	})
+
+	t.Run("Executable Rejected", func(t *testing.T) {
+		files := []File{{Path: "a.out", Base64Value: encode([]byte{0x7F, 'E', 'L', 'F'})}}
+		err := applyFilePolicy(gormDb, files)
+		if assert.IsType(t, &RejectedFileError{}, err, "executable not rejected") {
+			assert.Equal(t, "a.out", err.(*RejectedFileError).Path, "wrong file rejected")
+		}
+	})
+
+	t.Run("Oversized File", func(t *testing.T) {
+		maxFileSize := int64(8)
+		if !assert.NoError(t, ControllerEditJournalSettings(&EditJournalSettingsBody{MaxFileSize: &maxFileSize})) {
+			return
+		}
+		files := []File{{Path: "big.txt", Base64Value: encode([]byte("more than eight bytes"))}}
+		assert.IsType(t, &RejectedFileError{}, applyFilePolicy(gormDb, files), "oversized file not rejected")
+	})
+}
@@ -9,11 +9,16 @@
 package main
 
 import (
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
 	"gorm.io/gorm"
@@ -21,8 +26,17 @@ import (
 
 const (
 	ENDPOINT_COMMENT = "/comment"
+	ENDPOINT_RESOLVE = "/resolve"
+	ENDPOINT_REOPEN  = "/reopen"
+	ENDPOINT_REACT   = "/react"
 )
 
+// the fixed set of emoji users can react to comments with
+var commentReactions = map[string]bool{
+	"+1": true, "-1": true, "laugh": true, "hooray": true,
+	"confused": true, "heart": true, "rocket": true, "eyes": true,
+}
+
 // -----------
 // Router Functions
 // -----------
@@ -52,9 +66,15 @@ func PostUploadUserComment(w http.ResponseWriter, r *http.Request) {
 		// creates the comment using the given helper method
 	} else if commentID, err := addComment(&Comment{AuthorID: ctx.ID, FileID: uint(fileID64),
 		ParentID: req.ParentID, Base64Value: req.Base64Value, StartLine: req.StartLine, EndLine: req.EndLine}); err != nil {
-		log.Printf("[ERROR] Comment creation failed: %v", err)
-		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - Comment creation failed.", Error: true}
-		w.WriteHeader(http.StatusInternalServerError)
+		switch err.(type) {
+		case *FileNotFoundError, *CommentNotFoundError, *ParentCommentError, *LineRangeError:
+			resp.StandardResponse = StandardResponse{Message: "Bad Request - " + err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			log.Printf("[ERROR] Comment creation failed: %v", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - Comment creation failed.", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
 	} else {
 		resp.ID = commentID
 	}
@@ -129,7 +149,13 @@ func ControllerEditComment(commentID uint, r *EditCommentPostBody, authorID stri
 		if err := tx.Model(comment).Update("base64_value", r.Base64Value).Error; err != nil {
 			return err
 		}
-		return nil
+		// users newly mentioned in the edited comment are notified
+		comment.Base64Value = r.Base64Value
+		file := &File{}
+		if err := tx.Select("id, submission_id").Find(file, comment.FileID).Error; err != nil {
+			return err
+		}
+		return recordMentions(tx, comment, file.SubmissionID)
 	}); err != nil {
 		return err
 	}
@@ -205,11 +231,250 @@ func ControllerDeleteComment(commentID uint, authorID string) error {
 	return nil
 }
 
+// resolve comment thread
+// POST /file/{id}/comment/{commentId}/resolve
+func PostResolveComment(w http.ResponseWriter, r *http.Request) {
+	resolveThread(w, r, true)
+}
+
+// reopen resolved comment thread
+// POST /file/{id}/comment/{commentId}/reopen
+func PostReopenComment(w http.ResponseWriter, r *http.Request) {
+	resolveThread(w, r, false)
+}
+
+// resolves or reopens a comment thread for the resolve and reopen router functions
+func resolveThread(w http.ResponseWriter, r *http.Request, resolve bool) {
+	w.Header().Set("Content-Type", "application/json")
+	var resp *StandardResponse
+
+	fileID64, fileErr := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	commentID64, err := strconv.ParseUint(mux.Vars(r)["commentId"], 10, 32)
+	if fileErr != nil || err != nil {
+		resp = &StandardResponse{Message: "Bad Request - could not parse file or comment ID", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Bad Request - No user logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+		// resolves or reopens the thread using the given controller method
+	} else if err := ControllerResolveThread(uint(fileID64), uint(commentID64), resolve, ctx); err != nil {
+		switch err.(type) {
+		case *CommentNotFoundError:
+			resp = &StandardResponse{Message: "Given comment does not exist on the given file.", Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *NotThreadError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		case *WrongPermissionsError:
+			resp = &StandardResponse{Message: "Only the thread's author, the submission's authors and editors can resolve or reopen threads.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] Comment thread resolution failed: %v", err)
+			resp = &StandardResponse{Message: "Internal Server Error - Comment thread resolution failed.", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	} else if resolve {
+		resp = &StandardResponse{Message: "Comment Thread Resolved Successfully", Error: false}
+	} else {
+		resp = &StandardResponse{Message: "Comment Thread Reopened Successfully", Error: false}
+	}
+
+	// Encode response - set as error if empty
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] JSON repsonse formatting failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// toggle the user's reaction to a comment
+// POST /file/{id}/comment/{commentId}/react
+func PostReactComment(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	resp := &ReactCommentResponse{}
+	req := &ReactCommentPostBody{}
+
+	fileID64, fileErr := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	commentID64, err := strconv.ParseUint(mux.Vars(r)["commentId"], 10, 32)
+	if fileErr != nil || err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Bad Request - could not parse file or comment ID", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Bad Request - No user logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(req); err != nil || validate.Struct(req) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Bad Request - Request format is invalid.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+		// toggles the reaction using the given controller method
+	} else if resp.Reacted, err = ControllerToggleReaction(uint(fileID64), uint(commentID64), req.Emoji, ctx); err != nil {
+		switch err.(type) {
+		case *CommentNotFoundError:
+			resp.StandardResponse = StandardResponse{Message: "Given comment does not exist on the given file.", Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Not authorized to access the given submission.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case *BadReactionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			log.Printf("[ERROR] Comment reaction failed: %v", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - Comment reaction failed.", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// Encode response - set as error if empty
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] JSON repsonse formatting failed: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Resolves or reopens the thread started by a comment. The thread's author,
+// the submission's authors and editors can do so.
+//
+// Params:
+//	fileID (uint) : the file the comment is on
+//	commentID (uint) : the comment starting the thread
+//	resolve (bool) : true to resolve the thread, false to reopen it
+//	ctx (*RequestContext) : the logged in user
+// Returns:
+//	(error) : a *CommentNotFoundError if the comment is not on the file, a *NotThreadError if it is a reply, another error if one occurs
+func ControllerResolveThread(fileID uint, commentID uint, resolve bool, ctx *RequestContext) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		comment, err := getFileComment(tx, fileID, commentID)
+		if err != nil {
+			return err
+		} else if comment.ParentID != nil {
+			return &NotThreadError{ID: commentID}
+		}
+		if comment.AuthorID != ctx.ID && ctx.UserType != USERTYPE_EDITOR {
+			if submission, err := getCommentSubmission(tx, comment); err != nil {
+				return err
+			} else if !isUserInList(ctx.ID, submission.Authors) {
+				return &WrongPermissionsError{userID: ctx.ID}
+			}
+		}
+
+		updates := map[string]interface{}{"resolved_by": nil, "resolved_at": nil}
+		if resolve {
+			updates = map[string]interface{}{"resolved_by": ctx.ID, "resolved_at": time.Now()}
+		}
+		return tx.Model(comment).Updates(updates).Error
+	})
+}
+
+// Adds a user's reaction to a comment, or removes it if they already reacted
+// with the same emoji. Users can only react to comments on submissions they
+// can view.
+//
+// Params:
+//	fileID (uint) : the file the comment is on
+//	commentID (uint) : the comment
+//	emoji (string) : one of the allowed reactions
+//	ctx (*RequestContext) : the logged in user, reacting
+// Returns:
+//	(bool) : true if the reaction was added, false if it was removed
+//	(error) : a *BadReactionError if the emoji is not allowed, a *CommentNotFoundError if the comment is not on the file,
+//		a *WrongPermissionsError if the user cannot view the submission, another error if one occurs
+func ControllerToggleReaction(fileID uint, commentID uint, emoji string, ctx *RequestContext) (bool, error) {
+	if !commentReactions[emoji] {
+		return false, &BadReactionError{Emoji: emoji}
+	}
+	reacted := false
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		if comment, err := getFileComment(tx, fileID, commentID); err != nil {
+			return err
+		} else if submission, err := getCommentSubmission(tx, comment); err != nil {
+			return err
+		} else if !canViewSubmission(submission, ctx) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		}
+		res := tx.Where("comment_id = ? AND user_id = ? AND emoji = ?", commentID, ctx.ID, emoji).
+			Delete(&CommentReaction{})
+		if res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected > 0 {
+			return nil
+		}
+		reacted = true
+		return tx.Create(&CommentReaction{CommentID: commentID, UserID: ctx.ID, Emoji: emoji}).Error
+	}); err != nil {
+		return false, err
+	}
+	return reacted, nil
+}
+
 // -----------
 // Helper Functions
 // -----------
 
-// Add a comment to a file
+// gets a comment, which must be on the given file
+func getFileComment(tx *gorm.DB, fileID uint, commentID uint) (*Comment, error) {
+	comment := &Comment{}
+	if res := tx.Where("file_id = ?", fileID).Limit(1).Find(comment, commentID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &CommentNotFoundError{ID: commentID}
+	}
+	return comment, nil
+}
+
+// gets the submission a comment's file belongs to, with its authors and reviewers set
+func getCommentSubmission(tx *gorm.DB, comment *Comment) (*Submission, error) {
+	submission := &Submission{}
+	if err := tx.Preload("Authors").Preload("Reviewers").Where("id = (?)",
+		tx.Model(&File{}).Select("submission_id").Where("id = ?", comment.FileID)).
+		Limit(1).Find(submission).Error; err != nil {
+		return nil, err
+	}
+	return submission, nil
+}
+
+// removes the resolved threads from a file's comments
+func hideResolvedThreads(comments []Comment) []Comment {
+	open := []Comment{}
+	for _, comment := range comments {
+		if comment.ResolvedAt == nil {
+			open = append(open, comment)
+		}
+	}
+	return open
+}
+
+// Counts the open comment threads of each file of a submission, across its versions.
+//
+// Params:
+//	submissionID (uint) : the submission
+// Returns:
+//	(map[uint]int) : the number of open threads by file ID, files without any left out
+//	(error) : an error if one occurs
+func getUnresolvedThreads(submissionID uint) (map[uint]int, error) {
+	counts := []struct {
+		FileID uint
+		Count  int
+	}{}
+	if err := gormDb.Model(&Comment{}).Select("file_id, COUNT(*) AS count").
+		Where("parent_id IS NULL AND resolved_at IS NULL").
+		Where("file_id IN (?)", gormDb.Model(&File{}).Select("id").Where("submission_id = ?", submissionID)).
+		Group("file_id").Scan(&counts).Error; err != nil {
+		return nil, err
+	}
+	unresolved := make(map[uint]int)
+	for _, count := range counts {
+		unresolved[count.FileID] = count.Count
+	}
+	return unresolved, nil
+}
+
+// Add a comment to a file. Comments starting threads must be on lines of
+// the file, a snapshot of which is kept with them. Replies must answer a
+// comment on the same file, and take its lines.
 //
 // Params:
 //	comment (*Comment) : The comment struct to add to the file
@@ -224,11 +489,65 @@ func addComment(comment *Comment) (uint, error) {
 	} else if comment.AuthorID == "" {
 		return 0, errors.New("The author must exist.")
 	}
-	// adds the comment to the comments table with foreign key fileId and parentID
-	file := &File{}
-	file.ID = comment.FileID
-	if err := gormDb.Model(file).Association("Comments").Append(comment); err != nil {
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		file := &File{}
+		if res := tx.Limit(1).Find(file, comment.FileID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &FileNotFoundError{ID: comment.FileID}
+		}
+
+		if comment.ParentID != nil {
+			parent := &Comment{}
+			if res := tx.Limit(1).Find(parent, *comment.ParentID); res.Error != nil {
+				return res.Error
+			} else if res.RowsAffected == 0 {
+				return &CommentNotFoundError{ID: *comment.ParentID}
+			} else if parent.FileID != comment.FileID {
+				return &ParentCommentError{ParentID: parent.ID, FileID: comment.FileID}
+			}
+			comment.StartLine, comment.EndLine = parent.StartLine, parent.EndLine
+			comment.Snapshot = ""
+		} else {
+			// checks the lines against the file's content, and keeps a snapshot of them
+			submission := &Submission{}
+			if err := tx.Select("id, created_at").Find(submission, file.SubmissionID).Error; err != nil {
+				return err
+			}
+			content, err := getFileContent(filepath.Join(getSubmissionDirectoryPath(*submission), fmt.Sprint(file.ID)))
+			if err != nil {
+				return err
+			}
+			lines := fileLines(content)
+			if err := checkLineRange(comment.StartLine, comment.EndLine, len(lines)); err != nil {
+				return err
+			}
+			comment.Snapshot = snapshotLines(lines, comment.StartLine, comment.EndLine)
+		}
+
+		// adds the comment to the comments table with foreign key fileId and parentID
+		if err := tx.Model(file).Association("Comments").Append(comment); err != nil {
+			return err
+		}
+		return recordMentions(tx, comment, file.SubmissionID)
+	}); err != nil {
 		return 0, err
 	}
 	return comment.ID, nil
 }
+
+// checks that lines numbered from 1 are in a file, lines 0 to 0 being the whole file
+func checkLineRange(startLine int, endLine int, lineCount int) error {
+	if (startLine == 0 && endLine == 0) || (startLine >= 1 && startLine <= endLine && endLine <= lineCount) {
+		return nil
+	}
+	return &LineRangeError{StartLine: startLine, EndLine: endLine, LineCount: lineCount}
+}
+
+// base64 encodes the lines a comment is on, none for a comment on the whole file
+func snapshotLines(lines []string, startLine int, endLine int) string {
+	if startLine == 0 {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(strings.Join(lines[startLine-1:endLine], "\n")))
+}
@@ -11,12 +11,14 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"gorm.io/gorm/clause"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
@@ -48,7 +50,7 @@ func TestUploadUserComment(t *testing.T) {
 	// Add submission, and test file linked to submission.
 	testSubmission.Authors = globalAuthors[:1]
 	testSubmission.Files = []File{testFile}
-	_, err = addSubmission(&testSubmission)
+	_, err = addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "error occurred while adding test submission: %v", err) {
 		return
 	}
@@ -141,7 +143,7 @@ func TestUploadUserComment(t *testing.T) {
 			assert.Equalf(t, http.StatusOK, resp.StatusCode, "HTTP request error: %d", resp.StatusCode)
 
 			// gets the added comment via its file to verify the parent -> child structure is correct
-			file, err := getFileData(fileID)
+			file, _, err := getFileData(fileID, nil)
 			assert.NoError(t, err, "error retrieving test file")
 			assert.Equal(t, 1, len(file.Comments), "comment array is incorrect length.")
 			addedReply := file.Comments[0].Comments[0]
@@ -198,7 +200,7 @@ func TestEditUserComment(t *testing.T) {
 	// Add submission, and test file linked to submission.
 	testSubmission.Authors = globalAuthors[:1]
 	testSubmission.Files = []File{testFile}
-	_, err = addSubmission(&testSubmission)
+	_, err = addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "error occurred while adding test submission: %v", err) {
 		return
 	}
@@ -325,7 +327,7 @@ func TestDeleteUserComment(t *testing.T) {
 	// Add submission, and test file linked to submission.
 	testSubmission.Authors = globalAuthors[:1]
 	testSubmission.Files = []File{testFile}
-	_, err = addSubmission(&testSubmission)
+	_, err = addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "error occurred while adding test submission: %v", err) {
 		return
 	}
@@ -431,6 +433,71 @@ func TestDeleteUserComment(t *testing.T) {
 	})
 }
 
+// Tests that users can only react to comments on submissions they can view, through the comment's file
+func TestReactComment(t *testing.T) {
+	testInit()
+	defer testEnd()
+
+	router := mux.NewRouter()
+	router.HandleFunc(SUBROUTE_FILE+"/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_REACT, PostReactComment)
+
+	// the test values added to the db and filesystem (saved here so it can be easily changed)
+	testFile := testFiles[0]
+	testSubmission := *testSubmissions[0].getCopy()
+
+	// Register test users.
+	globalAuthors, globalReviewers, err := initMockUsers(t)
+	if !assert.NoError(t, err, "error registering mock users") {
+		return
+	}
+
+	// Add submission, and test file linked to submission.
+	testSubmission.Authors = globalAuthors[:1]
+	testSubmission.Files = []File{testFile}
+	_, err = addSubmission(&testSubmission, EVENT_CREATED, "")
+	if !assert.NoErrorf(t, err, "error occurred while adding test submission: %v", err) {
+		return
+	}
+	if !assert.NoError(t, gormDb.Model(&File{}).Find(&testFile).Error, "error occurred while getting file ID") {
+		return
+	}
+	comment := &Comment{AuthorID: globalAuthors[0].ID, FileID: testFile.ID, Base64Value: "test"}
+	if !assert.NoError(t, gormDb.Create(comment).Error, "Comment unable to be added") {
+		return
+	}
+
+	// sends request to react to a comment
+	handleRequest := func(ctx *RequestContext, fileID uint, commentID uint) *http.Response {
+		reqBody, err := json.Marshal(&ReactCommentPostBody{Emoji: "+1"})
+		assert.NoErrorf(t, err, "Error formatting request body: %v", err)
+		queryRoute := fmt.Sprintf("%s/%d%s/%d%s", SUBROUTE_FILE, fileID, ENDPOINT_COMMENT, commentID, ENDPOINT_REACT)
+		req, w := httptest.NewRequest("POST", queryRoute, bytes.NewBuffer(reqBody)), httptest.NewRecorder()
+		rCtx := context.WithValue(req.Context(), "data", ctx)
+		router.ServeHTTP(w, req.WithContext(rCtx))
+		return w.Result()
+	}
+
+	t.Run("valid reaction", func(t *testing.T) {
+		ctx := &RequestContext{ID: globalAuthors[0].ID, UserType: USERTYPE_PUBLISHER}
+		resp := handleRequest(ctx, testFile.ID, comment.ID)
+		assert.Equalf(t, http.StatusOK, resp.StatusCode, "HTTP request error: %d", resp.StatusCode)
+	})
+
+	t.Run("Request Validation", func(t *testing.T) {
+		t.Run("Submission not visible", func(t *testing.T) {
+			ctx := &RequestContext{ID: globalReviewers[0].ID, UserType: USERTYPE_REVIEWER}
+			resp := handleRequest(ctx, testFile.ID, comment.ID)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "status code incorrect")
+		})
+
+		t.Run("Comment not on file", func(t *testing.T) {
+			ctx := &RequestContext{ID: globalAuthors[0].ID, UserType: USERTYPE_PUBLISHER}
+			resp := handleRequest(ctx, testFile.ID+1, comment.ID)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode, "status code incorrect")
+		})
+	})
+}
+
 // -------------
 // Helper Function Tests
 // -------------
@@ -460,7 +527,7 @@ func TestAddComment(t *testing.T) {
 
 	// Add submission, and test file linked to submission.
 	testSubmission.Files = []File{testFile}
-	_, err = addSubmission(&testSubmission)
+	_, err = addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "error occurred while adding test submission: %v", err) {
 		return
 	}
@@ -514,7 +581,7 @@ func TestAddComment(t *testing.T) {
 		}
 
 		// gets the full file back
-		file, err := getFileData(fileID)
+		file, _, err := getFileData(fileID, nil)
 		if !assert.NoError(t, err, "unable to retrieve file from db") {
 			return
 		}
@@ -538,3 +605,57 @@ func TestAddComment(t *testing.T) {
 		}
 	})
 }
+
+// tests that resolved threads are hidden, leaving the open threads in order
+func TestHideResolvedThreads(t *testing.T) {
+	resolvedAt := time.Now()
+	resolver := "author"
+	comments := []Comment{
+		{AuthorID: "first"},
+		{AuthorID: "second", ResolvedBy: &resolver, ResolvedAt: &resolvedAt},
+		{AuthorID: "third"},
+	}
+	open := hideResolvedThreads(comments)
+	switch {
+	case !assert.Len(t, open, 2, "wrong number of open threads"),
+		!assert.Equal(t, "first", open[0].AuthorID, "open threads out of order"),
+		!assert.Equal(t, "third", open[1].AuthorID, "open threads out of order"):
+		return
+	}
+	assert.Empty(t, hideResolvedThreads([]Comment{comments[1]}), "resolved thread not hidden")
+}
+
+// tests that comments must be on lines of their file, or on the whole file
+func TestCheckLineRange(t *testing.T) {
+	testCases := []struct {
+		name       string
+		start, end int
+		valid      bool
+	}{
+		{"whole file", 0, 0, true},
+		{"single line", 2, 2, true},
+		{"line range", 1, 3, true},
+		{"start after end", 3, 2, false},
+		{"start at 0", 0, 2, false},
+		{"past the end", 2, 4, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if err := checkLineRange(testCase.start, testCase.end, 3); testCase.valid {
+				assert.NoError(t, err, "valid line range rejected")
+			} else {
+				assert.IsType(t, &LineRangeError{}, err, "invalid line range accepted")
+			}
+		})
+	}
+}
+
+// tests that snapshots hold the lines commented on
+func TestSnapshotLines(t *testing.T) {
+	lines := []string{"first", "second", "third"}
+	switch {
+	case !assert.Equal(t, base64.StdEncoding.EncodeToString([]byte("second\nthird")), snapshotLines(lines, 2, 3), "wrong snapshot"),
+		!assert.Empty(t, snapshotLines(lines, 0, 0), "snapshot of whole file comment"):
+		return
+	}
+}
@@ -0,0 +1,280 @@
+// =========================================================================
+// conflicts.go
+// Created: October 19, 2026
+//
+// This file takes care of conflicts of interest between reviewers and the
+// submissions they are assigned to, whether detected from organisations and
+// co-authorships or declared by reviewers themselves
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_DECLARE_CONFLICT = "/declareconflict"
+	ENDPOINT_CONFLICTS        = "/conflicts"
+
+	// kinds of conflict of interest
+	CONFLICT_AUTHOR       = "author"       // the reviewer is an author of the submission
+	CONFLICT_ORGANIZATION = "organization" // the reviewer shares an organization with an author
+	CONFLICT_COAUTHOR     = "coauthor"     // the reviewer recently co-authored a submission with an author
+	CONFLICT_DECLARED     = "declared"     // the reviewer declared a conflict
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for reviewers to declare a conflict of interest with a
+// submission, which prevents them being assigned to it without an override
+// POST /submission/{id}/declareconflict
+func PostDeclareConflict(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Conflict of interest declared successfully", Error: false}
+	reqBody := &DeclareConflictBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_REVIEWER && ctx.UserType != USERTYPE_REVIEWER_PUBLISHER {
+		resp = &StandardResponse{Message: "The client must have reviewer permissions to declare a conflict of interest.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerDeclareConflict(reqBody.Reason, submissionID, ctx.ID); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not declare conflict of interest: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not declare conflict of interest", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to see the conflicts of interest declared for
+// a submission and the ones overridden when assigning its reviewers
+// GET /submission/{id}/conflicts
+func GetSubmissionConflicts(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionConflictsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view conflicts of interest.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Where("submission_id = ?", submissionID).Find(&resp.Declarations).Error; err != nil {
+		log.Printf("[ERROR] could not get conflict declarations: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get conflicts of interest", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if err := gormDb.Where("submission_id = ?", submissionID).Find(&resp.Overrides).Error; err != nil {
+		log.Printf("[ERROR] could not get conflict overrides: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get conflicts of interest", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which records a reviewer's declared conflict of interest with a
+// submission.
+//
+// Params:
+// 	reason (string) : the reviewer's description of the conflict
+// 	submissionID (uint) : the submission the conflict is with
+// 	reviewerID (string) : the reviewer declaring the conflict
+// Returns:
+// 	(error) : an error if one occurs
+func ControllerDeclareConflict(reason string, submissionID uint, reviewerID string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		if res := tx.Select("id").Limit(1).Find(&Submission{}, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+		return tx.Create(&ConflictDeclaration{SubmissionID: submissionID, ReviewerID: reviewerID, Reason: reason}).Error
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// co-authorship of a submission between a reviewer and an author
+type coauthorship struct {
+	ReviewerID   string
+	AuthorID     string
+	SubmissionID uint
+}
+
+// Finds the conflicts of interest proposed reviewers have with a submission.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submissionID (uint) : the submission reviewers are proposed for
+// 	reviewerIDs ([]string) : the proposed reviewers
+// Returns:
+// 	([]ConflictReason) : the conflicts found, empty if there are none
+// 	(error) : an error if one occurs
+func findConflicts(tx *gorm.DB, submissionID uint, reviewerIDs []string) ([]ConflictReason, error) {
+	submission := &Submission{}
+	if err := tx.Preload("Authors.User").Find(submission, submissionID).Error; err != nil {
+		return nil, err
+	}
+	reviewers := []GlobalUser{}
+	if err := tx.Preload("User").Where("id IN ?", reviewerIDs).Find(&reviewers).Error; err != nil {
+		return nil, err
+	}
+
+	// gets submissions the reviewers recently co-authored with the submission's authors
+	coauthorships := []coauthorship{}
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return nil, err
+	}
+	authorIDs := []string{}
+	for _, author := range submission.Authors {
+		authorIDs = append(authorIDs, author.ID)
+	}
+	if settings.CoauthorConflictYears > 0 && len(authorIDs) > 0 && len(reviewers) > 0 {
+		if err := tx.Table("authors_submission AS reviewer").
+			Select("reviewer.global_user_id AS reviewer_id, author.global_user_id AS author_id, reviewer.submission_id").
+			Joins("JOIN authors_submission AS author ON author.submission_id = reviewer.submission_id").
+			Joins("JOIN submissions ON submissions.id = reviewer.submission_id").
+			Where("reviewer.global_user_id IN ? AND author.global_user_id IN ?", reviewerIDs, authorIDs).
+			Where("reviewer.submission_id <> ? AND submissions.deleted_at IS NULL AND submissions.created_at >= ?",
+				submissionID, time.Now().AddDate(-settings.CoauthorConflictYears, 0, 0)).
+			Scan(&coauthorships).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	declarations := []ConflictDeclaration{}
+	if err := tx.Where("submission_id = ? AND reviewer_id IN ?", submissionID, reviewerIDs).Find(&declarations).Error; err != nil {
+		return nil, err
+	}
+	return detectConflicts(submission.Authors, reviewers, coauthorships, declarations), nil
+}
+
+// Detects conflicts of interest between reviewers and the authors of a
+// submission, giving one conflict per reviewer, kind and author.
+//
+// Params:
+// 	authors ([]GlobalUser) : the submission's authors, with their profiles set
+// 	reviewers ([]GlobalUser) : the proposed reviewers, with their profiles set
+// 	coauthorships ([]coauthorship) : recent submissions co-authored by reviewers and authors
+// 	declarations ([]ConflictDeclaration) : conflicts declared by the reviewers for the submission
+// Returns:
+// 	([]ConflictReason) : the conflicts found, ordered by reviewer
+func detectConflicts(authors []GlobalUser, reviewers []GlobalUser, coauthorships []coauthorship, declarations []ConflictDeclaration) []ConflictReason {
+	conflicts := []ConflictReason{}
+	authorNames := make(map[string]string)
+	for _, author := range authors {
+		authorNames[author.ID] = strings.TrimSpace(author.FirstName + " " + author.LastName)
+	}
+	for _, reviewer := range reviewers {
+		if _, ok := authorNames[reviewer.ID]; ok {
+			conflicts = append(conflicts, ConflictReason{ReviewerID: reviewer.ID, Kind: CONFLICT_AUTHOR,
+				Detail: "reviewer is an author of the submission"})
+			continue
+		}
+		for _, author := range authors {
+			if reviewer.User != nil && author.User != nil && strings.TrimSpace(reviewer.User.Organization) != "" &&
+				strings.EqualFold(strings.TrimSpace(reviewer.User.Organization), strings.TrimSpace(author.User.Organization)) {
+				conflicts = append(conflicts, ConflictReason{ReviewerID: reviewer.ID, Kind: CONFLICT_ORGANIZATION,
+					Detail: fmt.Sprintf("shares organization %s with %s", strings.TrimSpace(author.User.Organization), authorNames[author.ID])})
+			}
+		}
+		coauthors := make(map[string][]string)
+		for _, coauthored := range coauthorships {
+			if coauthored.ReviewerID == reviewer.ID && coauthored.AuthorID != reviewer.ID {
+				coauthors[coauthored.AuthorID] = append(coauthors[coauthored.AuthorID], fmt.Sprint(coauthored.SubmissionID))
+			}
+		}
+		for _, author := range authors {
+			if submissionIDs, ok := coauthors[author.ID]; ok {
+				conflicts = append(conflicts, ConflictReason{ReviewerID: reviewer.ID, Kind: CONFLICT_COAUTHOR,
+					Detail: fmt.Sprintf("co-authored submission(s) %s with %s", strings.Join(submissionIDs, ", "), authorNames[author.ID])})
+			}
+		}
+		for _, declaration := range declarations {
+			if declaration.ReviewerID == reviewer.ID {
+				conflicts = append(conflicts, ConflictReason{ReviewerID: reviewer.ID, Kind: CONFLICT_DECLARED, Detail: declaration.Reason})
+			}
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		return conflicts[i].ReviewerID < conflicts[j].ReviewerID
+	})
+	return conflicts
+}
+
+// records the conflicts of interest an editor overrode when assigning
+// reviewers, one record per reviewer
+func recordConflictOverrides(tx *gorm.DB, submissionID uint, editorID string, conflicts []ConflictReason) error {
+	reasons := make(map[string][]string)
+	reviewerIDs := []string{}
+	for _, conflict := range conflicts {
+		if _, ok := reasons[conflict.ReviewerID]; !ok {
+			reviewerIDs = append(reviewerIDs, conflict.ReviewerID)
+		}
+		reasons[conflict.ReviewerID] = append(reasons[conflict.ReviewerID], conflict.Kind+": "+conflict.Detail)
+	}
+	for _, reviewerID := range reviewerIDs {
+		if err := tx.Create(&ConflictOverride{
+			SubmissionID: submissionID,
+			ReviewerID:   reviewerID,
+			EditorID:     editorID,
+			Reasons:      strings.Join(reasons[reviewerID], "\n"),
+		}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
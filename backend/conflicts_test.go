@@ -0,0 +1,53 @@
+// =====================================
+// conflicts_test.go
+// Created: October 19, 2026
+//
+// test file for conflicts.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that each kind of conflict of interest is detected
+func TestDetectConflicts(t *testing.T) {
+	authors := []GlobalUser{
+		{ID: "author1", FirstName: "Jane", LastName: "Doe", User: &User{Organization: "St Andrews"}},
+		{ID: "author2", FirstName: "John", LastName: "Roe", User: &User{}},
+	}
+	reviewers := []GlobalUser{
+		{ID: "reviewer1", User: &User{Organization: " st andrews"}},
+		{ID: "reviewer2", User: &User{}},
+		{ID: "reviewer3", User: &User{}},
+		{ID: "author2", User: &User{}},
+		{ID: "reviewer4", User: &User{}},
+	}
+	coauthorships := []coauthorship{
+		{ReviewerID: "reviewer2", AuthorID: "author2", SubmissionID: 4},
+		{ReviewerID: "reviewer2", AuthorID: "author2", SubmissionID: 7},
+	}
+	declarations := []ConflictDeclaration{{ReviewerID: "reviewer3", Reason: "former supervisor"}}
+
+	conflicts := detectConflicts(authors, reviewers, coauthorships, declarations)
+	switch {
+	case !assert.Len(t, conflicts, 4, "wrong number of conflicts"),
+		!assert.Equal(t, ConflictReason{ReviewerID: "author2", Kind: CONFLICT_AUTHOR, Detail: "reviewer is an author of the submission"},
+			conflicts[0], "author not detected"),
+		!assert.Equal(t, ConflictReason{ReviewerID: "reviewer1", Kind: CONFLICT_ORGANIZATION, Detail: "shares organization St Andrews with Jane Doe"},
+			conflicts[1], "organization not detected"),
+		!assert.Equal(t, ConflictReason{ReviewerID: "reviewer2", Kind: CONFLICT_COAUTHOR, Detail: "co-authored submission(s) 4, 7 with John Roe"},
+			conflicts[2], "co-authorship not detected"),
+		!assert.Equal(t, ConflictReason{ReviewerID: "reviewer3", Kind: CONFLICT_DECLARED, Detail: "former supervisor"},
+			conflicts[3], "declaration not detected"):
+		return
+	}
+	assert.Empty(t, detectConflicts(authors, reviewers[4:], coauthorships, declarations), "conflict found for unrelated reviewer")
+}
@@ -54,11 +54,12 @@ type User struct {
 
 // User global identification.
 type GlobalUser struct {
-	ID        string `gorm:"not null;primaryKey;type:varchar(191)" json:"userId" validate:"required"`
-	UserType  int    `gorm:"default:0" json:"userType"`
-	FirstName string `json:"firstName" validate:"required,max=32"`
-	LastName  string `json:"lastName" validate:"required,max=32"`
-	User      *User  `json:"profile,omitempty"`
+	ID          string `gorm:"not null;primaryKey;type:varchar(191)" json:"userId" validate:"required"`
+	UserType    int    `gorm:"default:0" json:"userType"`
+	ChiefEditor bool   `gorm:"default:false" json:"chiefEditor"` // chief editors can act on every submission, whoever handles it
+	FirstName   string `json:"firstName" validate:"required,max=32"`
+	LastName    string `json:"lastName" validate:"required,max=32"`
+	User        *User  `json:"profile,omitempty"`
 
 	AuthoredSubmissions []Submission `gorm:"many2many:authors_submission" json:"authoredSubmissions" validate:"dive"`
 	ReviewedSubmissions []Submission `gorm:"many2many:reviewers_submission" json:"reviewedSubmissions" validate:"dive"`
@@ -81,9 +82,14 @@ type Server struct {
 type Submission struct {
 	// actual table fields
 	gorm.Model
-	Name     string `gorm:"not null;size:128;index" json:"name" validate:"max=118"`
-	License  string `gorm:"size:64" json:"license" validate:"max=118"`
-	Approved *bool  `json:"approved" gorm:"default:NULL"` // pointer to allow nil values as neither approved nor dissaproved
+	Name             string  `gorm:"not null;size:128;index" json:"name" validate:"max=118"`
+	License          string  `gorm:"size:64" json:"license" validate:"max=118"`
+	Approved         *bool   `json:"approved" gorm:"default:NULL"`                     // pointer to allow nil values as neither approved nor dissaproved, kept in sync with Status
+	Status           string  `gorm:"size:32;default:submitted;index" json:"status"`    // state in the review workflow (see workflow.go)
+	Version          uint    `gorm:"default:1" json:"version"`                         // current version, incremented on each resubmission
+	ReviewMode       string  `gorm:"size:16" json:"reviewMode,omitempty"`              // blind review mode, empty to use the journal's
+	PublishReviews   bool    `gorm:"default:false" json:"publishReviews"`              // authors opted in to publishing the reviews once accepted
+	HandlingEditorID *string `gorm:"size:191;index" json:"handlingEditorId,omitempty"` // editor in charge of the submission, nil if any editor can handle it
 	
 	// booleans for running code using Judge0. All fields in this section only get used if Runnable = true
 	Runnable bool   `json:"runnable" gorm:"default:false"`
@@ -93,13 +99,20 @@ type Submission struct {
 	ReqNetworkAccess bool `json:"reqNetworkAccess" gorm:"default:false"`
 
 	// associations to other tables
-	Files      []File       `json:"files,omitempty" validate:"dive"`
-	Authors    []GlobalUser `gorm:"many2many:authors_submission" json:"authors,omitempty" validate:"required,dive"`
-	Reviewers  []GlobalUser `gorm:"many2many:reviewers_submission" json:"reviewers,omitempty"`
-	Categories []Category   `gorm:"many2many:categories_submissions" json:"categories,omitempty"` // tags for organizing/grouping code submissions (i.e. python)
+	Files          []File       `json:"files,omitempty" validate:"dive"`
+	Authors        []GlobalUser `gorm:"many2many:authors_submission" json:"authors,omitempty" validate:"required,dive"`
+	Reviewers      []GlobalUser `gorm:"many2many:reviewers_submission" json:"reviewers,omitempty"`
+	HandlingEditor *GlobalUser  `gorm:"foreignKey:HandlingEditorID" json:"handlingEditor,omitempty"`
+	Categories     []Category   `gorm:"many2many:categories_submissions" json:"categories,omitempty"` // tags for organizing/grouping code submissions (i.e. python)
+	Dependencies   []Dependency `json:"dependencies,omitempty"`                                       // parsed from the submission's dependency manifests
 
 	// stored in filesystem, not db
 	MetaData *SubmissionData `gorm:"-" json:"metaData,omitempty"`
+
+	// number of known vulnerabilities by severity, only set for editors
+	VulnerabilityCounts map[string]int `gorm:"-" json:"vulnerabilityCounts,omitempty"`
+	// scores of the current version's reviews aggregated by rubric criterion
+	RubricScores []CriterionAggregate `gorm:"-" json:"rubricScores,omitempty"`
 }
 
 // structure for meta-data of the submission. matches the structure of the submission's
@@ -113,8 +126,14 @@ type SubmissionData struct {
 type File struct {
 	// stored in files table
 	gorm.Model
-	SubmissionID uint   `json:"submissionId"` // foreign key linking files and submissions tables
-	Path         string `json:"path"`         // this path is relative from submission root
+	SubmissionID uint   `json:"submissionId"`             // foreign key linking files and submissions tables
+	Path         string `json:"path"`                     // this path is relative from submission root
+	Version      uint   `gorm:"default:1" json:"version"` // version of the submission the file belongs to
+
+	// classification of the file's content, set on upload
+	Classification string `gorm:"size:16;default:text" json:"classification"`
+	Size           int64  `json:"size"`                            // size of the decoded content in bytes
+	Attachment     bool   `gorm:"default:false" json:"attachment"` // attachments are not shown in the code viewer
 
 	// association to comments table
 	Comments []Comment `json:"comments,omitempty"`
@@ -128,6 +147,33 @@ type Review struct {
 	ReviewerID  string `json:"reviewerId"`
 	Approved    bool   `json:"approved"`
 	Base64Value string `json:"base64Value"`
+	Round       uint   `json:"round"` // version of the submission reviewed (0 for reviews predating versions)
+	Named       bool   `json:"named"` // the reviewer is named if the review is published, anonymous otherwise
+
+	// scores against the rubric active when the review was uploaded
+	RubricID uint             `json:"rubricId,omitempty"` // 0 if no rubric was active
+	Scores   []CriterionScore `json:"scores,omitempty"`
+
+	// history of the review, only shown to editors
+	WithdrawnAt   *time.Time        `json:"withdrawnAt,omitempty"`   // withdrawn reviews are kept but no longer count
+	ArchivedAt    *time.Time        `json:"archivedAt,omitempty"`    // reviews of unassigned reviewers are archived and no longer count
+	ArchiveReason string            `json:"archiveReason,omitempty"` // the reason the reviewer was unassigned
+	Revisions     []*ReviewRevision `json:"revisions,omitempty"`     // previous contents of the review, oldest first
+}
+
+// Structure for the previous contents of an edited review
+type ReviewRevision struct {
+	Approved    bool             `json:"approved"`
+	Base64Value string           `json:"base64Value"`
+	Scores      []CriterionScore `json:"scores,omitempty"`
+	ReplacedAt  time.Time        `json:"replacedAt"`
+}
+
+// Structure for a review's score of one rubric criterion
+type CriterionScore struct {
+	CriterionID   uint   `json:"criterionId" validate:"required"`
+	Score         int    `json:"score"`
+	Justification string `json:"justification,omitempty"`
 }
 
 // Structure for user comments on code
@@ -138,10 +184,53 @@ type Comment struct {
 	Base64Value string `gorm:"type:mediumtext" json:"base64Value"`
 	StartLine   int    `json:"startLine"`
 	EndLine     int    `json:"endLine"`
+	Snapshot    string `gorm:"type:mediumtext" json:"snapshot,omitempty"` // base64 encoded lines commented on, as they were
+
+	// anchor the comment was written on, set once it is moved onto a new version of its file
+	OriginalFileID    *uint `json:"originalFileId,omitempty"`
+	OriginalStartLine int   `json:"originalStartLine,omitempty"`
+	OriginalEndLine   int   `json:"originalEndLine,omitempty"`
+	Outdated          bool  `gorm:"default:false" json:"outdated"` // lines the comment is on were changed or removed
+
+	// resolution of the thread started by the comment, nil while it is open
+	ResolvedBy *string    `gorm:"size:191" json:"resolvedBy,omitempty"`
+	ResolvedAt *time.Time `json:"resolvedAt,omitempty"`
 
 	// self association for replies to user comments
-	ParentID *uint     `gorm:"default:NULL" json:"parentId,omitempty"` // pointer so it can be nil
-	Comments []Comment `gorm:"foreignKey:ParentID" json:"comments,omitempty"`
+	ParentID  *uint             `gorm:"default:NULL" json:"parentId,omitempty"` // pointer so it can be nil
+	Comments  []Comment         `gorm:"foreignKey:ParentID" json:"comments,omitempty"`
+	Reactions []CommentReaction `json:"reactions,omitempty"`
+	Mentions  []CommentMention  `json:"mentions,omitempty"`
+}
+
+// A user mentioned in a comment's body, as @userId or @First Last (see mentions.go)
+type CommentMention struct {
+	ID        uint   `gorm:"primaryKey" json:"-"`
+	CommentID uint   `gorm:"not null;uniqueIndex:idx_comment_mention" json:"-"`
+	UserID    string `gorm:"not null;size:191;uniqueIndex:idx_comment_mention" json:"userId"`
+	Text      string `gorm:"size:128" json:"text"` // the mention as written, i.e. "@First Last"
+}
+
+// A notification for a user, i.e. of being mentioned in a comment (see notifications.go)
+type Notification struct {
+	ID           uint       `gorm:"primaryKey" json:"id"`
+	UserID       string     `gorm:"not null;size:191;index" json:"-"`
+	Type         string     `gorm:"not null;size:32" json:"type"`
+	SubmissionID uint       `json:"submissionId"`
+	FileID       uint       `json:"fileId,omitempty"`
+	CommentID    uint       `json:"commentId,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	ReadAt       *time.Time `json:"readAt,omitempty"` // nil while unread
+}
+
+// A user's reaction to a comment, from a fixed set of emoji (see comments.go).
+// Each user reacts at most once with each emoji
+type CommentReaction struct {
+	ID        uint      `gorm:"primaryKey" json:"-"`
+	CommentID uint      `gorm:"not null;uniqueIndex:idx_comment_reaction" json:"-"`
+	UserID    string    `gorm:"not null;size:191;uniqueIndex:idx_comment_reaction" json:"userId"`
+	Emoji     string    `gorm:"not null;size:16;uniqueIndex:idx_comment_reaction" json:"emoji"`
+	CreatedAt time.Time `json:"createdAt"`
 }
 
 // stores submission tags (i.e. networking, java, python, etc.)
@@ -153,6 +242,255 @@ type Category struct {
 	DeletedAt time.Time `json:"-"`
 }
 
+// Journal-wide settings, stored as a single row.
+type JournalSettings struct {
+	ID               uint   `gorm:"primaryKey" json:"-"`
+	SecretScanPolicy string `gorm:"size:16;default:block" json:"secretScanPolicy"`
+
+	// policies for uploaded files depending on their classification
+	BinaryFilePolicy     string `gorm:"size:16;default:attachment" json:"binaryFilePolicy"`
+	ExecutableFilePolicy string `gorm:"size:16;default:reject" json:"executableFilePolicy"`
+	ArchiveFilePolicy    string `gorm:"size:16;default:attachment" json:"archiveFilePolicy"`
+	OversizedFilePolicy  string `gorm:"size:16;default:reject" json:"oversizedFilePolicy"`
+	MaxFileSize          int64  `gorm:"default:10485760" json:"maxFileSize"` // in bytes, of the decoded file
+
+	// blind review, which submissions can override
+	ReviewMode        string `gorm:"size:16;default:open" json:"reviewMode"`
+	RedactAuthorNames bool   `gorm:"default:false" json:"redactAuthorNames"` // redacts author names in file headers in double-blind review
+
+	// reviewers who co-authored a submission with an author in this many years have a conflict of interest (0 to disable)
+	CoauthorConflictYears int `gorm:"default:3" json:"coauthorConflictYears"`
+
+	// review invitations and deadlines, in days
+	ReviewPeriodDays     int `gorm:"default:21" json:"reviewPeriodDays"`    // default time given to write a review once invited
+	InvitationExpiryDays int `gorm:"default:7" json:"invitationExpiryDays"` // invitations not accepted in time expire
+	ReminderDays         int `gorm:"default:3" json:"reminderDays"`         // reviewers are reminded this long before their deadline
+
+	// rule deciding whether a submission can be accepted, which tags can override (see policies.go)
+	AcceptancePolicy string `gorm:"size:16;default:unanimous" json:"acceptancePolicy"`
+	MinApprovals     int    `gorm:"default:1" json:"minApprovals"` // approvals required by the min_approvals policy
+
+	// open peer review: reviews of accepted submissions whose authors opt in are published with them
+	PublishReviews bool `gorm:"default:false" json:"publishReviews"`
+
+	UpdatedAt time.Time `json:"-"`
+}
+
+// Credential found in a submission file during upload. The matched value
+// itself is never stored, only a redacted preview of it.
+type SecretFinding struct {
+	gorm.Model
+	SubmissionID uint   `gorm:"index" json:"submissionId"`
+	FileID       uint   `json:"fileId"`
+	Path         string `json:"path"`
+	Line         int    `json:"line"`
+	Rule         string `gorm:"size:64" json:"rule"`
+	Preview      string `gorm:"size:64" json:"preview"`
+}
+
+// Winnowed k-gram hash of a file's normalised token stream, used to detect
+// similar code across submissions.
+type Fingerprint struct {
+	ID           uint  `gorm:"primaryKey"`
+	SubmissionID uint  `gorm:"index"`
+	FileID       uint  `gorm:"index"`
+	Hash         int64 `gorm:"index"`
+}
+
+// Dependency declared in one of a submission's manifests (i.e. go.mod).
+type Dependency struct {
+	ID                uint   `gorm:"primaryKey" json:"-"`
+	SubmissionID      uint   `gorm:"index" json:"-"`
+	SubmissionVersion uint   `gorm:"default:1" json:"-"`
+	Manifest          string `json:"manifest"` // path of the manifest declaring the dependency
+	Ecosystem         string `gorm:"size:32" json:"ecosystem"`
+	Name              string `json:"name"`
+	Version           string `json:"version"` // exact version if pinned, version constraint otherwise
+	Pinned            bool   `json:"pinned"`
+	Dev               bool   `json:"dev"` // only needed for development or testing
+}
+
+// Security advisory imported from an OSV-format advisory dump.
+type Advisory struct {
+	ID             string            `gorm:"primaryKey;size:64" json:"id"`
+	Summary        string            `json:"summary"`
+	Aliases        string            `json:"aliases"` // comma separated (i.e. CVE identifiers)
+	Severity       string            `gorm:"size:16" json:"severity"`
+	SeverityVector string            `gorm:"size:160" json:"severityVector,omitempty"` // CVSS vector if the advisory gives one
+	Modified       time.Time         `json:"modified"`
+	Affected       []AffectedPackage `json:"affected,omitempty"`
+}
+
+// Range of versions of a package affected by an advisory. Versions listed
+// explicitly by the advisory are stored on a row without range bounds.
+type AffectedPackage struct {
+	ID           uint   `gorm:"primaryKey" json:"-"`
+	AdvisoryID   string `gorm:"size:64;index" json:"-"`
+	Ecosystem    string `gorm:"size:32;index:idx_affected_package" json:"ecosystem"`
+	Name         string `gorm:"size:191;index:idx_affected_package" json:"name"`
+	RangeType    string `gorm:"size:16" json:"rangeType"` // SEMVER or ECOSYSTEM
+	Introduced   string `json:"introduced"`
+	Fixed        string `json:"fixed"`
+	LastAffected string `json:"lastAffected"`
+	Versions     string `gorm:"type:text" json:"versions"` // newline separated
+}
+
+// Advisory matching one of a submission's pinned dependencies.
+type Vulnerability struct {
+	ID                uint   `gorm:"primaryKey" json:"-"`
+	SubmissionID      uint   `gorm:"index" json:"-"`
+	SubmissionVersion uint   `json:"submissionVersion"`
+	DependencyID      uint   `json:"-"`
+	AdvisoryID        string `gorm:"size:64;index" json:"advisoryId"`
+	Ecosystem         string `gorm:"size:32" json:"ecosystem"`
+	Package           string `json:"package"`
+	Version           string `json:"version"`
+	Manifest          string `json:"manifest"`
+	Severity          string `gorm:"size:16" json:"severity"`
+	Summary           string `json:"summary"`
+	FixedIn           string `json:"fixedIn"` // comma separated versions fixing the advisory, empty if there is no fix
+}
+
+// Review rubric defined by editors. Rubrics apply journal-wide or only to
+// submissions with their tag, and reviews are scored against their criteria.
+type Rubric struct {
+	gorm.Model
+	Name     string            `gorm:"size:128" json:"name"`
+	Tag      string            `gorm:"size:191;index" json:"tag,omitempty"` // empty for journal-wide rubrics
+	Active   bool              `gorm:"index" json:"active"`                 // only active rubrics are used for new reviews
+	Criteria []RubricCriterion `json:"criteria"`
+}
+
+// Conflict of interest declared by a reviewer for a submission.
+type ConflictDeclaration struct {
+	gorm.Model
+	SubmissionID uint   `gorm:"index" json:"submissionId"`
+	ReviewerID   string `gorm:"size:191;index" json:"reviewerId"`
+	Reason       string `gorm:"size:512" json:"reason"`
+}
+
+// Reviewer assigned to a submission despite conflicts of interest, recorded
+// when an editor overrides them.
+type ConflictOverride struct {
+	gorm.Model
+	SubmissionID uint   `gorm:"index" json:"submissionId"`
+	ReviewerID   string `gorm:"size:191" json:"reviewerId"`
+	EditorID     string `gorm:"size:191" json:"editorId"`
+	Reasons      string `gorm:"type:text" json:"reasons"` // newline separated descriptions of the conflicts
+}
+
+// Invitation of a reviewer to review a submission. Invited and accepted
+// reviewers are the submission's reviewers, declined and expired invitations
+// are kept for editors after the reviewer is removed from the submission.
+type ReviewInvitation struct {
+	gorm.Model
+	SubmissionID  uint       `gorm:"index" json:"submissionId"`
+	ReviewerID    string     `gorm:"size:191;index" json:"reviewerId"`
+	Status        string     `gorm:"size:16;default:invited;index" json:"status"` // invited, accepted, declined or expired
+	DeclineReason string     `gorm:"size:512" json:"declineReason,omitempty"`
+	DueAt         time.Time  `json:"dueAt"`
+	RespondedAt   *time.Time `json:"respondedAt,omitempty"`
+	RemindedAt    *time.Time `json:"remindedAt,omitempty"`  // set once a reminder is sent before the deadline
+	CompletedAt   *time.Time `json:"completedAt,omitempty"` // set once the current version is reviewed
+	Overdue       bool       `gorm:"default:false" json:"overdue"`
+}
+
+// Reviewer unassigned from a submission by an editor, possibly replaced by
+// another reviewer.
+type ReviewerRemoval struct {
+	gorm.Model
+	SubmissionID   uint   `gorm:"index" json:"submissionId"`
+	ReviewerID     string `gorm:"size:191" json:"reviewerId"`
+	ReplacementID  string `gorm:"size:191" json:"replacementId,omitempty"` // empty if the reviewer was not replaced
+	EditorID       string `gorm:"size:191" json:"editorId"`
+	Reason         string `gorm:"size:512" json:"reason"`
+	ReviewArchived bool   `gorm:"default:false" json:"reviewArchived"` // whether the reviewer had reviewed the current version
+}
+
+// Acceptance policy overriding the journal's for submissions with a tag.
+type AcceptancePolicy struct {
+	gorm.Model
+	Tag          string `gorm:"size:191;uniqueIndex" json:"tag"`
+	Policy       string `gorm:"size:16" json:"policy"`
+	MinApprovals int    `json:"minApprovals"` // approvals required by the min_approvals policy
+}
+
+// Record of an editor accepting or rejecting a submission, with the
+// acceptance policy applied and the reviews it was applied to.
+type SubmissionDecision struct {
+	gorm.Model
+	SubmissionID  uint   `gorm:"index" json:"submissionId"`
+	Version       uint   `json:"version"`
+	EditorID      string `gorm:"size:191" json:"editorId"`
+	Status        string `gorm:"size:32" json:"status"` // accepted or rejected
+	Policy        string `gorm:"size:16" json:"policy"`
+	PolicyTag     string `gorm:"size:191" json:"policyTag,omitempty"` // tag the policy was set for, empty for the journal's
+	MinApprovals  int    `json:"minApprovals,omitempty"`
+	Reviewers     int    `json:"reviewers"` // number of reviewers assigned
+	Reviews       int    `json:"reviews"`   // number of reviews of the current version
+	Approvals     int    `json:"approvals"` // number of approving reviews of the current version
+	Justification string `gorm:"type:text" json:"justification,omitempty"`
+
+	// letter written to the authors, and the deadline it gives them
+	Letter   string     `gorm:"type:text" json:"letter,omitempty"`
+	Deadline *time.Time `json:"deadline,omitempty"`
+}
+
+// Appeal filed by an author against the rejection of their submission, with
+// rebuttals of specific reviews. An editor other than the one who rejected
+// the submission upholds the decision or reopens the submission.
+type Appeal struct {
+	gorm.Model
+	SubmissionID uint             `gorm:"index" json:"submissionId"`
+	DecisionID   uint             `json:"decisionId"` // the rejection appealed against
+	AuthorID     string           `gorm:"size:191" json:"authorId"`
+	Statement    string           `gorm:"type:text" json:"statement,omitempty"`
+	Rebuttals    []AppealRebuttal `json:"rebuttals"`
+	Status       string           `gorm:"size:16" json:"status"`
+	HandlerID    string           `gorm:"size:191" json:"handlerId,omitempty"` // editor who resolved the appeal
+	Resolution   string           `gorm:"type:text" json:"resolution,omitempty"`
+	ResolvedAt   *time.Time       `json:"resolvedAt,omitempty"`
+}
+
+// An author's rebuttal of one of the reviews their appeal is against.
+type AppealRebuttal struct {
+	ID         uint   `gorm:"primaryKey" json:"-"`
+	AppealID   uint   `gorm:"index" json:"-"`
+	ReviewerID string `gorm:"size:191" json:"reviewerId"`
+	Rebuttal   string `gorm:"type:text" json:"rebuttal"`
+}
+
+// Template of a decision letter, managed by the journal's editors (see letters.go).
+type LetterTemplate struct {
+	gorm.Model
+	Name string `gorm:"size:128;uniqueIndex" json:"name"`
+	Body string `gorm:"type:text" json:"body"` // may contain {{placeholders}}
+}
+
+// Entry of a submission's append-only history of lifecycle events (see history.go).
+type SubmissionEvent struct {
+	ID           uint      `gorm:"primaryKey" json:"id"`
+	SubmissionID uint      `gorm:"index" json:"submissionId"`
+	Type         string    `gorm:"size:32" json:"type"`
+	ActorID      string    `gorm:"size:191" json:"actorId,omitempty"` // empty for events no user caused (i.e. imports)
+	Payload      string    `gorm:"type:text" json:"-"`                // JSON encoded details of the event
+	EditorsOnly  bool      `gorm:"default:false" json:"-"`            // hidden from authors and reviewers
+	CreatedAt    time.Time `json:"createdAt"`
+
+	Details map[string]string `gorm:"-" json:"payload"` // decoded payload, set when the history is read
+}
+
+// Criterion of a rubric, scored between MinScore and MaxScore (inclusive).
+type RubricCriterion struct {
+	ID                    uint   `gorm:"primaryKey" json:"id"`
+	RubricID              uint   `gorm:"index" json:"-"`
+	Name                  string `gorm:"size:64" json:"name"`
+	Description           string `json:"description"`
+	MinScore              int    `json:"minScore"`
+	MaxScore              int    `json:"maxScore"`
+	RequiresJustification bool   `json:"requiresJustification"`
+}
+
 // ---- Database and reflect utilities ----
 
 // Initialise database - open connection, migrate tables, set logger.
@@ -167,7 +505,16 @@ func gormInit(dbname string, logger logger.Interface) (*gorm.DB, error) {
 	if err != nil {
 		goto ERR
 	}
-	err = db.AutoMigrate(&GlobalUser{}, &User{}, &Server{}, &Category{}, &Submission{}, &File{}, &Comment{})
+	// gives statuses to submissions stored before they existed, before the other columns are added
+	if err = migrateSubmissionStatus(db); err != nil {
+		goto ERR
+	}
+	err = db.AutoMigrate(&GlobalUser{}, &User{}, &Server{}, &Category{}, &Submission{}, &File{}, &Comment{},
+		&JournalSettings{}, &SecretFinding{}, &Fingerprint{}, &Dependency{},
+		&Advisory{}, &AffectedPackage{}, &Vulnerability{}, &Rubric{}, &RubricCriterion{},
+		&ConflictDeclaration{}, &ConflictOverride{}, &ReviewInvitation{}, &ReviewerRemoval{},
+		&AcceptancePolicy{}, &SubmissionDecision{}, &SubmissionEvent{}, &LetterTemplate{},
+		&Appeal{}, &AppealRebuttal{}, &CommentReaction{}, &CommentMention{}, &Notification{})
 	if err != nil {
 		goto ERR
 	}
@@ -221,7 +568,11 @@ func gormClear(db *gorm.DB) error {
 		db.Select(clause.Associations).Unscoped().Delete(&submission)
 	}
 	// Deletes main tables
-	tables := []interface{}{&Comment{}, &File{}, &Category{}, &User{}, &GlobalUser{}, &Submission{}}
+	tables := []interface{}{&Notification{}, &CommentMention{}, &CommentReaction{}, &Comment{}, &File{}, &Category{}, &User{}, &GlobalUser{}, &Submission{},
+		&JournalSettings{}, &SecretFinding{}, &Fingerprint{}, &Dependency{}, &AffectedPackage{}, &Advisory{},
+		&Vulnerability{}, &RubricCriterion{}, &Rubric{}, &ConflictDeclaration{}, &ConflictOverride{},
+		&ReviewInvitation{}, &ReviewerRemoval{}, &AcceptancePolicy{}, &SubmissionDecision{},
+		&LetterTemplate{}, &AppealRebuttal{}, &Appeal{}}
 	for _, table := range tables {
 		res := db.Session(&gorm.Session{AllowGlobalUpdate: true}).
 			Unscoped().Delete(table)
@@ -229,7 +580,8 @@ func gormClear(db *gorm.DB) error {
 			return err
 		}
 	}
-	return nil
+	// submission events refuse deletion through gorm, as they are append-only
+	return db.Exec("DELETE FROM submission_events").Error
 }
 
 // Returns true if a field exists on given table and no row exists with given field with given value.
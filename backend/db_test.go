@@ -119,7 +119,7 @@ var testFiles []File = []File{
 
 var testComments []*Comment = []*Comment{
 	{AuthorID: "", Base64Value: "Hello World", Comments: []Comment{}, StartLine: 0, EndLine: 0},
-	{AuthorID: "", Base64Value: "Goodbye World", Comments: []Comment{}, StartLine: 0, EndLine: 1},
+	{AuthorID: "", Base64Value: "Goodbye World", Comments: []Comment{}, StartLine: 0, EndLine: 0},
 }
 
 var testLogger logger.Interface = logger.New(log.New(os.Stdout, "\r\n", log.LstdFlags), logger.Config{
@@ -0,0 +1,496 @@
+// =========================================================================
+// dependencies.go
+// Created: October 19, 2026
+//
+// This file takes care of parsing dependency manifests found in submissions
+// (go.mod, requirements.txt, pyproject.toml, package.json, Cargo.toml and
+// pom.xml) into a normalised dependency list
+// =========================================================================
+
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"log"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// ecosystem names, matching those used by OSV advisories
+	ECOSYSTEM_GO    = "Go"
+	ECOSYSTEM_PYPI  = "PyPI"
+	ECOSYSTEM_NPM   = "npm"
+	ECOSYSTEM_CARGO = "crates.io"
+	ECOSYSTEM_MAVEN = "Maven"
+)
+
+// manifest parsers by manifest file base name
+var manifestParsers = map[string]func(content string) ([]Dependency, error){
+	"go.mod":           parseGoMod,
+	"requirements.txt": parseRequirementsTxt,
+	"pyproject.toml":   parsePyprojectToml,
+	"package.json":     parsePackageJson,
+	"Cargo.toml":       parseCargoToml,
+	"pom.xml":          parsePomXml,
+}
+
+// directories holding third party code, whose manifests are not the submission's own
+var vendoredDirectories = []string{"node_modules", "vendor", "site-packages", ".venv", "venv"}
+
+var requirementMatcher = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(\[[^\]]*\])?\s*([^;]*?)\s*(;.*)?$`)
+var pinnedRequirementMatcher = regexp.MustCompile(`^===?\s*([^\s,*]+)$`)
+var pinnedSemverMatcher = regexp.MustCompile(`^=?\s*v?(\d+\.\d+\.\d+(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?)$`)
+var pinnedPoetryMatcher = regexp.MustCompile(`^(==?)?\s*(\d+(\.\d+)*([A-Za-z0-9.+-]*)?)$`)
+var pinnedCargoMatcher = regexp.MustCompile(`^=\s*(\d+\.\d+\.\d+([-+][0-9A-Za-z.+-]+)?)$`)
+var pythonNameSeparatorMatcher = regexp.MustCompile(`[-_.]+`)
+var mavenPropertyMatcher = regexp.MustCompile(`\$\{([^}]+)\}`)
+var tomlStringMatcher = regexp.MustCompile(`"([^"]*)"|'([^']*)'`)
+var tomlVersionMatcher = regexp.MustCompile(`\bversion\s*=\s*("[^"]*"|'[^']*')`)
+
+// ------
+// Helper Functions
+// ------
+
+// Parses the dependency manifests among a submission's files and stores the
+// dependencies found. Manifests which cannot be parsed are skipped.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction the files are being added in
+// 	submissionID (uint) : the submission the files belong to
+// 	version (uint) : the version of the submission the files belong to
+// 	files ([]File) : the submission's files, with content set as base64
+// Returns:
+// 	(error) : an error if the dependencies cannot be stored
+func addDependencies(tx *gorm.DB, submissionID uint, version uint, files []File) error {
+	dependencies := []Dependency{}
+	for _, file := range files {
+		if file.Classification != FILE_CLASS_TEXT {
+			continue
+		}
+		manifestDependencies, err := parseManifest(file.Path, string(decodeFileContent(file.Base64Value)))
+		if err != nil {
+			log.Printf("[WARN] Could not parse dependency manifest %s of submission %d: %v", file.Path, submissionID, err)
+			continue
+		}
+		for _, dependency := range manifestDependencies {
+			dependency.SubmissionID = submissionID
+			dependency.SubmissionVersion = version
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	if len(dependencies) == 0 {
+		return nil
+	}
+	return tx.Create(&dependencies).Error
+}
+
+// Parses a file as a dependency manifest if it is one.
+//
+// Params:
+// 	path (string) : the file's path, relative to the submission root
+// 	content (string) : the file's content
+// Returns:
+// 	([]Dependency) : the manifest's dependencies, nil if the file is not a manifest
+// 	(error) : an error if the manifest is malformed
+func parseManifest(path string, content string) ([]Dependency, error) {
+	for _, directory := range strings.Split(filepath.Dir(path), "/") {
+		for _, vendored := range vendoredDirectories {
+			if directory == vendored {
+				return nil, nil
+			}
+		}
+	}
+	name := filepath.Base(path)
+	if match, _ := filepath.Match("requirements*.txt", name); match {
+		name = "requirements.txt"
+	}
+	parser, ok := manifestParsers[name]
+	if !ok {
+		return nil, nil
+	}
+	dependencies, err := parser(content)
+	if err != nil {
+		return nil, err
+	}
+	for i := range dependencies {
+		dependencies[i].Manifest = path
+	}
+	sort.SliceStable(dependencies, func(i, j int) bool {
+		return dependencies[i].Name < dependencies[j].Name
+	})
+	return dependencies, nil
+}
+
+// Parses go.mod require directives. Go module versions are always exact.
+func parseGoMod(content string) ([]Dependency, error) {
+	dependencies := []Dependency{}
+	inRequireBlock := false
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(line, "//"); index != -1 {
+			line = strings.TrimSpace(line[:index])
+		}
+		switch {
+		case line == "require (":
+			inRequireBlock = true
+			continue
+		case inRequireBlock && line == ")":
+			inRequireBlock = false
+			continue
+		case strings.HasPrefix(line, "require "):
+			line = strings.TrimSpace(strings.TrimPrefix(line, "require"))
+		case !inRequireBlock:
+			continue
+		}
+		if fields := strings.Fields(line); len(fields) == 2 {
+			dependencies = append(dependencies, Dependency{
+				Ecosystem: ECOSYSTEM_GO, Name: fields[0], Version: fields[1], Pinned: true,
+			})
+		}
+	}
+	return dependencies, scanner.Err()
+}
+
+// Parses a pip requirements file. Only == and === requirements are pinned.
+func parseRequirementsTxt(content string) ([]Dependency, error) {
+	dependencies := []Dependency{}
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if index := strings.Index(line, "#"); index != -1 {
+			line = strings.TrimSpace(line[:index])
+		}
+		// options, editable installs and direct URLs do not name a released package
+		if line == "" || strings.HasPrefix(line, "-") || strings.Contains(line, "://") {
+			continue
+		}
+		if dependency, ok := parsePythonRequirement(line); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, scanner.Err()
+}
+
+// parses a single PEP 508 requirement (i.e. "requests[security]>=2.0; python_version>'3'")
+func parsePythonRequirement(requirement string) (Dependency, bool) {
+	match := requirementMatcher.FindStringSubmatch(strings.TrimSpace(requirement))
+	if match == nil {
+		return Dependency{}, false
+	}
+	dependency := Dependency{Ecosystem: ECOSYSTEM_PYPI, Name: normalisePythonName(match[1]), Version: match[3]}
+	if pinned := pinnedRequirementMatcher.FindStringSubmatch(match[3]); pinned != nil {
+		dependency.Version = pinned[1]
+		dependency.Pinned = true
+	}
+	return dependency, true
+}
+
+// normalises a python package name as PyPI does (PEP 503)
+func normalisePythonName(name string) string {
+	return strings.ToLower(pythonNameSeparatorMatcher.ReplaceAllString(name, "-"))
+}
+
+// Parses PEP 621 and poetry dependencies from a pyproject.toml file.
+func parsePyprojectToml(content string) ([]Dependency, error) {
+	tables, err := parseToml(content)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := []Dependency{}
+
+	// PEP 621 requirement lists
+	for _, requirement := range tomlStringArray(tables["project"]["dependencies"]) {
+		if dependency, ok := parsePythonRequirement(requirement); ok {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	for _, requirements := range tables["project.optional-dependencies"] {
+		for _, requirement := range tomlStringArray(requirements) {
+			if dependency, ok := parsePythonRequirement(requirement); ok {
+				dependency.Dev = true
+				dependencies = append(dependencies, dependency)
+			}
+		}
+	}
+
+	// poetry dependency tables
+	for table, values := range tables {
+		isDev := table == "tool.poetry.dev-dependencies" ||
+			(strings.HasPrefix(table, "tool.poetry.group.") && strings.HasSuffix(table, ".dependencies"))
+		if table != "tool.poetry.dependencies" && !isDev {
+			continue
+		}
+		for name, value := range values {
+			if name == "python" {
+				continue
+			}
+			constraint := tomlDependencyVersion(value)
+			dependency := Dependency{Ecosystem: ECOSYSTEM_PYPI, Name: normalisePythonName(name), Version: constraint, Dev: isDev}
+			if pinned := pinnedPoetryMatcher.FindStringSubmatch(constraint); pinned != nil {
+				dependency.Version = pinned[2]
+				dependency.Pinned = true
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	return dependencies, nil
+}
+
+// Parses package.json dependencies. Only exact versions are pinned.
+func parsePackageJson(content string) ([]Dependency, error) {
+	manifest := struct {
+		Dependencies    map[string]string `json:"dependencies"`
+		DevDependencies map[string]string `json:"devDependencies"`
+	}{}
+	if err := json.Unmarshal([]byte(content), &manifest); err != nil {
+		return nil, err
+	}
+	dependencies := []Dependency{}
+	add := func(packages map[string]string, dev bool) {
+		for name, constraint := range packages {
+			dependency := Dependency{Ecosystem: ECOSYSTEM_NPM, Name: name, Version: constraint, Dev: dev}
+			if pinned := pinnedSemverMatcher.FindStringSubmatch(strings.TrimSpace(constraint)); pinned != nil {
+				dependency.Version = pinned[1]
+				dependency.Pinned = true
+			}
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	add(manifest.Dependencies, false)
+	add(manifest.DevDependencies, true)
+	return dependencies, nil
+}
+
+// Parses Cargo.toml dependencies. Cargo versions are caret requirements
+// unless prefixed by "=", so only those are pinned.
+func parseCargoToml(content string) ([]Dependency, error) {
+	tables, err := parseToml(content)
+	if err != nil {
+		return nil, err
+	}
+	dependencies := []Dependency{}
+	add := func(name string, constraint string, dev bool) {
+		dependency := Dependency{Ecosystem: ECOSYSTEM_CARGO, Name: name, Version: constraint, Dev: dev}
+		if pinned := pinnedCargoMatcher.FindStringSubmatch(constraint); pinned != nil {
+			dependency.Version = pinned[1]
+			dependency.Pinned = true
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	for table, values := range tables {
+		// dependency tables may be nested under a target (i.e. target.'cfg(unix)'.dependencies)
+		section := table
+		if parts := strings.SplitN(table, ".", 3); parts[0] == "target" && len(parts) == 3 {
+			section = parts[2]
+		}
+		switch {
+		case isCargoDependencyTable(section):
+			for name, value := range values {
+				add(name, tomlDependencyVersion(value), strings.HasPrefix(section, "dev-"))
+			}
+		case isCargoDependencyTable(dependencyTablePrefix(section)):
+			// dependency declared as its own table (i.e. [dependencies.serde])
+			name := section[strings.Index(section, ".")+1:]
+			version, _ := tomlString(values["version"])
+			add(name, version, strings.HasPrefix(section, "dev-"))
+		}
+	}
+	return dependencies, nil
+}
+
+// checks whether a table name is one of cargo's dependency tables
+func isCargoDependencyTable(table string) bool {
+	return table == "dependencies" || table == "dev-dependencies" || table == "build-dependencies"
+}
+
+// gets the part of a table name before its first dot
+func dependencyTablePrefix(table string) string {
+	if index := strings.Index(table, "."); index != -1 {
+		return table[:index]
+	}
+	return table
+}
+
+// Parses pom.xml dependencies, resolving ${property} versions. Version ranges,
+// snapshots and missing (parent managed) versions are unpinned.
+func parsePomXml(content string) ([]Dependency, error) {
+	pom := struct {
+		Version    string `xml:"version"`
+		Properties struct {
+			Entries []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		} `xml:"properties"`
+		Dependencies []struct {
+			GroupID    string `xml:"groupId"`
+			ArtifactID string `xml:"artifactId"`
+			Version    string `xml:"version"`
+			Scope      string `xml:"scope"`
+		} `xml:"dependencies>dependency"`
+	}{}
+	if err := xml.Unmarshal([]byte(content), &pom); err != nil {
+		return nil, err
+	}
+	properties := map[string]string{"project.version": pom.Version}
+	for _, entry := range pom.Properties.Entries {
+		properties[entry.XMLName.Local] = strings.TrimSpace(entry.Value)
+	}
+
+	dependencies := []Dependency{}
+	for _, pomDependency := range pom.Dependencies {
+		version := mavenPropertyMatcher.ReplaceAllStringFunc(strings.TrimSpace(pomDependency.Version), func(property string) string {
+			if value, ok := properties[property[2:len(property)-1]]; ok {
+				return value
+			}
+			return property
+		})
+		dependencies = append(dependencies, Dependency{
+			Ecosystem: ECOSYSTEM_MAVEN,
+			Name:      strings.TrimSpace(pomDependency.GroupID) + ":" + strings.TrimSpace(pomDependency.ArtifactID),
+			Version:   version,
+			Pinned: version != "" && !strings.ContainsAny(version, "[](),$") &&
+				!strings.HasSuffix(version, "-SNAPSHOT") && version != "LATEST" && version != "RELEASE",
+			Dev: strings.TrimSpace(pomDependency.Scope) == "test",
+		})
+	}
+	return dependencies, nil
+}
+
+// ------
+// TOML Helpers
+// ------
+
+// Parses the subset of TOML used by dependency manifests into raw values by
+// table then key. Values are kept as written (strings quoted, inline tables
+// and arrays unparsed), with multi-line arrays joined onto one line.
+func parseToml(content string) (map[string]map[string]string, error) {
+	tables := map[string]map[string]string{"": {}}
+	table := ""
+	pendingKey, pendingValue := "", ""
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(stripTomlComment(scanner.Text()))
+		if pendingKey != "" {
+			// continuation of a multi-line array
+			pendingValue += " " + line
+			if tomlBracketsBalanced(pendingValue) {
+				tables[table][pendingKey] = pendingValue
+				pendingKey = ""
+			}
+			continue
+		}
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "[") && !strings.HasSuffix(line, "]"):
+			return nil, &BadManifestError{Line: line}
+		case strings.HasPrefix(line, "[["):
+			table = "[[" + strings.Trim(line, "[] ") + "]]" // arrays of tables are kept apart
+			tables[table] = map[string]string{}
+		case strings.HasPrefix(line, "["):
+			table = normaliseTomlKey(strings.Trim(line, "[] "))
+			if tables[table] == nil {
+				tables[table] = map[string]string{}
+			}
+		default:
+			index := strings.Index(line, "=")
+			if index == -1 {
+				return nil, &BadManifestError{Line: line}
+			}
+			key, value := normaliseTomlKey(line[:index]), strings.TrimSpace(line[index+1:])
+			if tomlBracketsBalanced(value) {
+				tables[table][key] = value
+			} else {
+				pendingKey, pendingValue = key, value
+			}
+		}
+	}
+	if pendingKey != "" {
+		return nil, &BadManifestError{Line: pendingValue}
+	}
+	return tables, scanner.Err()
+}
+
+// removes quotes around the parts of a dotted TOML key
+func normaliseTomlKey(key string) string {
+	parts := strings.Split(strings.TrimSpace(key), ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return strings.Join(parts, ".")
+}
+
+// removes a TOML comment from a line, ignoring # characters inside strings
+func stripTomlComment(line string) string {
+	var quote rune
+	for i, c := range line {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote == 0 && (c == '"' || c == '\''):
+			quote = c
+		case quote == 0 && c == '#':
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// checks whether every bracket and brace opened in a TOML value is closed
+func tomlBracketsBalanced(value string) bool {
+	depth := 0
+	var quote rune
+	for _, c := range value {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '[' || c == '{':
+			depth++
+		case c == ']' || c == '}':
+			depth--
+		}
+	}
+	return depth <= 0
+}
+
+// gets the content of a TOML string value
+func tomlString(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1], true
+	}
+	return "", false
+}
+
+// gets the strings in a TOML array value
+func tomlStringArray(value string) []string {
+	strs := []string{}
+	for _, match := range tomlStringMatcher.FindAllStringSubmatch(value, -1) {
+		strs = append(strs, match[1]+match[2])
+	}
+	return strs
+}
+
+// gets the version constraint of a dependency declared either as a string or
+// as an inline table (i.e. { version = "1.0", features = ["derive"] })
+func tomlDependencyVersion(value string) string {
+	if version, ok := tomlString(value); ok {
+		return version
+	}
+	if match := tomlVersionMatcher.FindStringSubmatch(value); match != nil {
+		version, _ := tomlString(match[1])
+		return version
+	}
+	return ""
+}
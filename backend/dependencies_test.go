@@ -0,0 +1,295 @@
+// =====================================
+// dependencies_test.go
+// Created: October 19, 2026
+//
+// test file for dependencies.go and sbom.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_GO_MOD = `module example.com/project
+
+go 1.17
+
+require github.com/gorilla/mux v1.8.0
+
+require (
+	gorm.io/gorm v1.22.3 // indirect
+	github.com/stretchr/testify v1.7.0
+)
+`
+	TEST_REQUIREMENTS_TXT = `# runtime dependencies
+requests[security]==2.26.0
+Django>=3.2,<4
+numpy
+-r other-requirements.txt
+git+https://github.com/example/project.git
+typing_extensions===4.0.1 ; python_version < "3.8"
+`
+	TEST_PYPROJECT_TOML = `[project]
+name = "example"
+dependencies = [
+    "httpx==0.21.1",
+    "rich>=10",  # for output
+]
+
+[project.optional-dependencies]
+test = ["pytest==6.2.5"]
+
+[tool.poetry.dependencies]
+python = "^3.8"
+Flask = "2.0.2"
+click = { version = "^8.0", optional = true }
+
+[tool.poetry.group.dev.dependencies]
+black = "==21.12b0"
+`
+	TEST_PACKAGE_JSON = `{
+	"name": "example",
+	"dependencies": {
+		"react": "17.0.2",
+		"axios": "^0.24.0",
+		"@mui/material": "=5.2.3"
+	},
+	"devDependencies": {
+		"jest": "~27.4.0"
+	}
+}`
+	TEST_CARGO_TOML = `[package]
+name = "example"
+
+[dependencies]
+serde = { version = "=1.0.130", features = ["derive"] }
+rand = "0.8"
+
+[dev-dependencies]
+criterion = "=0.3.5"
+
+[target.'cfg(unix)'.dependencies]
+libc = "0.2"
+
+[dependencies.tokio]
+version = "1.14"
+features = ["full"]
+`
+	TEST_POM_XML = `<project>
+	<version>1.0.0</version>
+	<properties>
+		<junit.version>4.13.2</junit.version>
+	</properties>
+	<dependencies>
+		<dependency>
+			<groupId>com.google.guava</groupId>
+			<artifactId>guava</artifactId>
+			<version>31.0.1-jre</version>
+		</dependency>
+		<dependency>
+			<groupId>junit</groupId>
+			<artifactId>junit</artifactId>
+			<version>${junit.version}</version>
+			<scope>test</scope>
+		</dependency>
+		<dependency>
+			<groupId>org.slf4j</groupId>
+			<artifactId>slf4j-api</artifactId>
+			<version>[1.7,2.0)</version>
+		</dependency>
+		<dependency>
+			<groupId>org.example</groupId>
+			<artifactId>managed</artifactId>
+		</dependency>
+	</dependencies>
+</project>`
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// finds a dependency by name in a list of dependencies
+func findDependency(dependencies []Dependency, name string) *Dependency {
+	for i := range dependencies {
+		if dependencies[i].Name == name {
+			return &dependencies[i]
+		}
+	}
+	return nil
+}
+
+// checks the parsed version and pinned state of a list of dependencies by name
+func assertDependencies(t *testing.T, dependencies []Dependency, expected map[string]Dependency) {
+	assert.Len(t, dependencies, len(expected), "Wrong number of dependencies parsed")
+	for name, expectedDependency := range expected {
+		dependency := findDependency(dependencies, name)
+		if !assert.NotNil(t, dependency, "Dependency %s not parsed", name) {
+			continue
+		}
+		assert.Equal(t, expectedDependency.Version, dependency.Version, "Wrong version for %s", name)
+		assert.Equal(t, expectedDependency.Pinned, dependency.Pinned, "Wrong pinned state for %s", name)
+		assert.Equal(t, expectedDependency.Dev, dependency.Dev, "Wrong dev state for %s", name)
+	}
+}
+
+// Tests that each supported manifest type is parsed with its pinned versions detected
+func TestParseManifest(t *testing.T) {
+	t.Run("go.mod", func(t *testing.T) {
+		dependencies, err := parseManifest("go.mod", TEST_GO_MOD)
+		if !assert.NoError(t, err, "Error parsing go.mod") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"github.com/gorilla/mux":      {Version: "v1.8.0", Pinned: true},
+			"gorm.io/gorm":                {Version: "v1.22.3", Pinned: true},
+			"github.com/stretchr/testify": {Version: "v1.7.0", Pinned: true},
+		})
+		assert.Equal(t, ECOSYSTEM_GO, dependencies[0].Ecosystem, "Wrong ecosystem")
+		assert.Equal(t, "go.mod", dependencies[0].Manifest, "Manifest path not set")
+	})
+
+	t.Run("requirements.txt", func(t *testing.T) {
+		dependencies, err := parseManifest("src/requirements-dev.txt", TEST_REQUIREMENTS_TXT)
+		if !assert.NoError(t, err, "Error parsing requirements.txt") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"requests":          {Version: "2.26.0", Pinned: true},
+			"django":            {Version: ">=3.2,<4"},
+			"numpy":             {Version: ""},
+			"typing-extensions": {Version: "4.0.1", Pinned: true},
+		})
+	})
+
+	t.Run("pyproject.toml", func(t *testing.T) {
+		dependencies, err := parseManifest("pyproject.toml", TEST_PYPROJECT_TOML)
+		if !assert.NoError(t, err, "Error parsing pyproject.toml") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"httpx":  {Version: "0.21.1", Pinned: true},
+			"rich":   {Version: ">=10"},
+			"pytest": {Version: "6.2.5", Pinned: true, Dev: true},
+			"flask":  {Version: "2.0.2", Pinned: true},
+			"click":  {Version: "^8.0"},
+			"black":  {Version: "21.12b0", Pinned: true, Dev: true},
+		})
+	})
+
+	t.Run("package.json", func(t *testing.T) {
+		dependencies, err := parseManifest("frontend/package.json", TEST_PACKAGE_JSON)
+		if !assert.NoError(t, err, "Error parsing package.json") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"react":         {Version: "17.0.2", Pinned: true},
+			"axios":         {Version: "^0.24.0"},
+			"@mui/material": {Version: "5.2.3", Pinned: true},
+			"jest":          {Version: "~27.4.0", Dev: true},
+		})
+	})
+
+	t.Run("Cargo.toml", func(t *testing.T) {
+		dependencies, err := parseManifest("Cargo.toml", TEST_CARGO_TOML)
+		if !assert.NoError(t, err, "Error parsing Cargo.toml") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"serde":     {Version: "1.0.130", Pinned: true},
+			"rand":      {Version: "0.8"},
+			"criterion": {Version: "0.3.5", Pinned: true, Dev: true},
+			"libc":      {Version: "0.2"},
+			"tokio":     {Version: "1.14"},
+		})
+	})
+
+	t.Run("pom.xml", func(t *testing.T) {
+		dependencies, err := parseManifest("pom.xml", TEST_POM_XML)
+		if !assert.NoError(t, err, "Error parsing pom.xml") {
+			return
+		}
+		assertDependencies(t, dependencies, map[string]Dependency{
+			"com.google.guava:guava": {Version: "31.0.1-jre", Pinned: true},
+			"junit:junit":            {Version: "4.13.2", Pinned: true, Dev: true},
+			"org.slf4j:slf4j-api":    {Version: "[1.7,2.0)"},
+			"org.example:managed":    {Version: ""},
+		})
+	})
+
+	t.Run("Not a manifest", func(t *testing.T) {
+		dependencies, err := parseManifest("main.go", "package main")
+		assert.NoError(t, err, "Error parsing non-manifest file")
+		assert.Nil(t, dependencies, "Non-manifest file parsed as manifest")
+	})
+
+	t.Run("Vendored manifest", func(t *testing.T) {
+		dependencies, err := parseManifest("frontend/node_modules/axios/package.json", TEST_PACKAGE_JSON)
+		assert.NoError(t, err, "Error parsing vendored manifest")
+		assert.Nil(t, dependencies, "Vendored manifest parsed")
+	})
+
+	t.Run("Malformed manifest", func(t *testing.T) {
+		_, err := parseManifest("package.json", "{ not json")
+		assert.Error(t, err, "No error parsing malformed package.json")
+		_, err = parseManifest("Cargo.toml", "[dependencies\nserde = \"1.0\"")
+		assert.Error(t, err, "No error parsing malformed Cargo.toml")
+	})
+}
+
+// Tests that dependencies are exported as CycloneDX and SPDX documents
+func TestBuildSbom(t *testing.T) {
+	submission := &Submission{
+		Name: "Test Submission", License: "MIT",
+		Dependencies: []Dependency{
+			{ID: 1, Manifest: "package.json", Ecosystem: ECOSYSTEM_NPM, Name: "@mui/material", Version: "5.2.3", Pinned: true},
+			{ID: 2, Manifest: "package.json", Ecosystem: ECOSYSTEM_NPM, Name: "jest", Version: "~27.4.0", Dev: true},
+			{ID: 3, Manifest: "pom.xml", Ecosystem: ECOSYSTEM_MAVEN, Name: "junit:junit", Version: "4.13.2", Pinned: true},
+		},
+	}
+	submission.ID = 1
+
+	t.Run("CycloneDX", func(t *testing.T) {
+		bom := buildCycloneDxBom(submission)
+		switch {
+		case !assert.Len(t, bom.Components, 3, "Wrong number of components"):
+			return
+		case !assert.Equal(t, "pkg:npm/%40mui/material@5.2.3", bom.Components[0].Purl, "Wrong npm purl"):
+			return
+		case !assert.Equal(t, "5.2.3", bom.Components[0].Version, "Pinned version not set"):
+			return
+		case !assert.Empty(t, bom.Components[1].Version, "Unpinned dependency given a version"):
+			return
+		case !assert.Contains(t, bom.Components[1].Properties, CycloneDxProperty{Name: "versionConstraint", Value: "~27.4.0"}, "Version constraint not kept"):
+			return
+		case !assert.Equal(t, "optional", bom.Components[1].Scope, "Dev dependency not optional"):
+			return
+		case !assert.Equal(t, "pkg:maven/junit/junit@4.13.2", bom.Components[2].Purl, "Wrong maven purl"):
+			return
+		case !assert.Equal(t, "junit", bom.Components[2].Group, "Maven group not set"):
+			return
+		}
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		document := buildSpdxDocument(submission)
+		switch {
+		case !assert.Len(t, document.Packages, 4, "Wrong number of packages"):
+			return
+		case !assert.Equal(t, "MIT", document.Packages[0].LicenseConcluded, "Submission license not set"):
+			return
+		case !assert.Equal(t, "5.2.3", document.Packages[1].VersionInfo, "Pinned version not set"):
+			return
+		case !assert.Empty(t, document.Packages[2].VersionInfo, "Unpinned dependency given a version"):
+			return
+		case !assert.Len(t, document.Relationships, 4, "Wrong number of relationships"):
+			return
+		case !assert.Equal(t, "DEV_DEPENDENCY_OF", document.Relationships[2].RelationshipType, "Dev dependency relationship not set"):
+			return
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package main
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // ------------------------------------------------------------------- //
 // Collection of error types for route and controller error handling.
@@ -20,6 +23,16 @@ func (e *NoSubmissionError) Error() string {
 	return fmt.Sprintf("Submission %d doesn't exist!", e.ID)
 }
 
+// version of a submission does not exist
+type NoSubmissionVersionError struct {
+	SubmissionID uint
+	Version      uint
+}
+
+func (e *NoSubmissionVersionError) Error() string {
+	return fmt.Sprintf("Submission %d has no version %d!", e.SubmissionID, e.Version)
+}
+
 // submission was marked runnable but has no run.sh file
 type SubmissionNotRunnableError struct{}
 
@@ -27,6 +40,19 @@ func (e *SubmissionNotRunnableError) Error() string {
 	return "Given submission is missing a run file"
 }
 
+// submission files contain credentials and the journal blocks such uploads
+type SecretsFoundError struct {
+	Findings []SecretFinding
+}
+
+func (e *SecretsFoundError) Error() string {
+	locations := make([]string, len(e.Findings))
+	for i, finding := range e.Findings {
+		locations[i] = fmt.Sprintf("%s:%d (%s)", finding.Path, finding.Line, finding.Rule)
+	}
+	return fmt.Sprintf("Submission contains %d possible secret(s): %s", len(e.Findings), strings.Join(locations, ", "))
+}
+
 // -----------
 // File Errors
 // -----------
@@ -49,6 +75,36 @@ func (e *DuplicateFileError) Error() string {
 	return fmt.Sprintf("Path %s appears more than once!", e.Path)
 }
 
+// file rejected by the journal's file policy (i.e. an executable)
+type RejectedFileError struct {
+	Path           string
+	Classification string
+	Size           int64
+}
+
+func (e *RejectedFileError) Error() string {
+	return fmt.Sprintf("File %s (%s, %d bytes) is not allowed by the journal's file policy", e.Path, e.Classification, e.Size)
+}
+
+// dependency manifest which cannot be parsed
+type BadManifestError struct {
+	Line string
+}
+
+func (e *BadManifestError) Error() string {
+	return fmt.Sprintf("Could not parse manifest line: %s", e.Line)
+}
+
+// advisory file of an OSV dump which cannot be decoded
+type BadAdvisoryError struct {
+	Path string
+	Err  error
+}
+
+func (e *BadAdvisoryError) Error() string {
+	return fmt.Sprintf("Could not decode advisory file %s: %v", e.Path, e.Err)
+}
+
 // -----------
 // Comments Errors
 // -----------
@@ -61,6 +117,45 @@ func (e *CommentNotFoundError) Error() string {
 	return fmt.Sprintf("Comment %d does not exist!", e.ID)
 }
 
+// handle case where a comment's lines are not in its file
+type LineRangeError struct {
+	StartLine int
+	EndLine   int
+	LineCount int
+}
+
+func (e *LineRangeError) Error() string {
+	return fmt.Sprintf("Lines %d to %d are not in the file, which has %d lines", e.StartLine, e.EndLine, e.LineCount)
+}
+
+// handle case where a reply answers a comment on another file
+type ParentCommentError struct {
+	ParentID uint
+	FileID   uint
+}
+
+func (e *ParentCommentError) Error() string {
+	return fmt.Sprintf("Comment %d is not on file %d", e.ParentID, e.FileID)
+}
+
+// handle case where a reply is resolved or reopened instead of the comment starting its thread
+type NotThreadError struct {
+	ID uint
+}
+
+func (e *NotThreadError) Error() string {
+	return fmt.Sprintf("Comment %d is a reply, only the comment starting a thread can be resolved or reopened", e.ID)
+}
+
+// handle case where a reaction is not one of the allowed emoji
+type BadReactionError struct {
+	Emoji string
+}
+
+func (e *BadReactionError) Error() string {
+	return fmt.Sprintf("%s is not an allowed reaction", e.Emoji)
+}
+
 // -----------
 // Approval Errors
 // -----------
@@ -85,6 +180,58 @@ func (e *DuplicateReviewError) Error() string {
 	return fmt.Sprintf("Reviewer %s submitted multiple reviews for submission %d", e.UserID, e.SubmissionID)
 }
 
+// Handle edits and withdrawals of reviews which do not exist.
+type NoReviewError struct {
+	UserID       string
+	SubmissionID uint
+}
+
+func (e *NoReviewError) Error() string {
+	return fmt.Sprintf("Reviewer %s has no review of the current version of submission %d", e.UserID, e.SubmissionID)
+}
+
+// handle review scores which do not match the rubric they are scored against
+type BadReviewScoresError struct {
+	Reason string
+}
+
+func (e *BadReviewScoresError) Error() string {
+	return fmt.Sprintf("Review scores do not match the rubric: %s", e.Reason)
+}
+
+// rubric does not exist
+type NoRubricError struct {
+	ID uint
+}
+
+func (e *NoRubricError) Error() string {
+	return fmt.Sprintf("Rubric %d doesn't exist!", e.ID)
+}
+
+// handle proposed reviewers having conflicts of interest with a submission
+type ConflictOfInterestError struct {
+	SubmissionID uint
+	Conflicts    []ConflictReason
+}
+
+func (e *ConflictOfInterestError) Error() string {
+	reasons := make([]string, len(e.Conflicts))
+	for i, conflict := range e.Conflicts {
+		reasons[i] = fmt.Sprintf("%s (%s: %s)", conflict.ReviewerID, conflict.Kind, conflict.Detail)
+	}
+	return fmt.Sprintf("Conflicts of interest with submission %d: %s", e.SubmissionID, strings.Join(reasons, ", "))
+}
+
+// handle responses to review invitations which are not pending
+type NoInvitationError struct {
+	UserID       string
+	SubmissionID uint
+}
+
+func (e *NoInvitationError) Error() string {
+	return fmt.Sprintf("Reviewer %s has no pending invitation to review submission %d", e.UserID, e.SubmissionID)
+}
+
 // handles case where a review is uploaded or reviewer is assigned to an already approved submission
 type SubmissionStatusFinalisedError struct {
 	SubmissionID uint
@@ -103,13 +250,119 @@ func (e *MissingReviewsError) Error() string {
 	return fmt.Sprintf("Cannot change status of submission: %d as it is missing reviews", e.SubmissionID)
 }
 
-// handle case where an editor tries to approve a submission without all reviews being approving reviews
+// handle case where an editor accepts a submission without the justification its acceptance policy requires
+type MissingJustificationError struct {
+	SubmissionID uint
+	Policy       string
+}
+
+func (e *MissingJustificationError) Error() string {
+	return fmt.Sprintf("Accepting submission %d under the %s policy requires a justification", e.SubmissionID, e.Policy)
+}
+
+// handle case where an appeal is filed against a submission which cannot be appealed
+type AppealNotAllowedError struct {
+	SubmissionID uint
+	Reason       string
+}
+
+func (e *AppealNotAllowedError) Error() string {
+	return fmt.Sprintf("Cannot appeal submission %d: %s", e.SubmissionID, e.Reason)
+}
+
+// handle case where a submission has no pending appeal to resolve
+type NoAppealError struct {
+	SubmissionID uint
+}
+
+func (e *NoAppealError) Error() string {
+	return fmt.Sprintf("Submission %d has no pending appeal", e.SubmissionID)
+}
+
+// handle case where the editor who rejected a submission tries to resolve the appeal against it
+type DecidingEditorError struct {
+	EditorID     string
+	SubmissionID uint
+}
+
+func (e *DecidingEditorError) Error() string {
+	return fmt.Sprintf("Editor %s rejected submission %d, so another editor must handle its appeal", e.EditorID, e.SubmissionID)
+}
+
+// handle case where an editor acts on a submission handled by another editor
+type NotHandlingEditorError struct {
+	EditorID     string
+	SubmissionID uint
+}
+
+func (e *NotHandlingEditorError) Error() string {
+	return fmt.Sprintf("Editor %s does not handle submission %d", e.EditorID, e.SubmissionID)
+}
+
+// handle case where the role of the journal's last chief editor is revoked
+type LastChiefEditorError struct {
+	UserID string
+}
+
+func (e *LastChiefEditorError) Error() string {
+	return fmt.Sprintf("Editor %s is the last chief editor, so their role cannot be revoked", e.UserID)
+}
+
+// handle case where a decision letter template does not exist
+type NoLetterTemplateError struct {
+	ID uint
+}
+
+func (e *NoLetterTemplateError) Error() string {
+	return fmt.Sprintf("Letter template %d doesn't exist!", e.ID)
+}
+
+// handle case where a letter template is given the name of another template
+type DuplicateLetterTemplateError struct {
+	Name string
+}
+
+func (e *DuplicateLetterTemplateError) Error() string {
+	return fmt.Sprintf("A letter template named %s already exists", e.Name)
+}
+
+// handle case where a letter template uses a placeholder which cannot be filled in
+type UnknownPlaceholderError struct {
+	Placeholder string
+}
+
+func (e *UnknownPlaceholderError) Error() string {
+	return fmt.Sprintf("Unknown placeholder {{%s}} in letter template", e.Placeholder)
+}
+
+// handle case where an editor tries to approve a submission without the approving reviews its acceptance policy requires
 type MissingApprovalError struct {
 	SubmissionID uint
 }
 
 func (e *MissingApprovalError) Error() string {
-	return fmt.Sprintf("Cannot change status of submission: %d as not all reviewers approve", e.SubmissionID)
+	return fmt.Sprintf("Cannot change status of submission: %d as not enough reviewers approve", e.SubmissionID)
+}
+
+// handle case where a submission is moved to a status its current status cannot lead to
+type InvalidStatusTransitionError struct {
+	SubmissionID uint
+	From         string
+	To           string
+}
+
+func (e *InvalidStatusTransitionError) Error() string {
+	return fmt.Sprintf("Submission %d cannot go from status %s to %s", e.SubmissionID, e.From, e.To)
+}
+
+// handle case where a review is uploaded for a submission which is not being reviewed
+type SubmissionNotUnderReviewError struct {
+	SubmissionID uint
+	Status       string
+}
+
+func (e *SubmissionNotUnderReviewError) Error() string {
+	return fmt.Sprintf("Submission %d is not under review (status: %s)", e.SubmissionID, e.Status)
 }
 
 // -----------
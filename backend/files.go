@@ -17,8 +17,10 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 
 	"github.com/gorilla/mux"
@@ -35,6 +37,9 @@ const (
 
 	DIR_PERMISSIONS  = 0755 // permissions for filesystem directories
 	FILE_PERMISSIONS = 0644 // permissions for submission files
+
+	MAX_COMMENT_DEPTH    = 8   // deepest level of nested replies, deeper replies are flattened into it
+	MAX_THREAD_PAGE_SIZE = 100 // largest page of comment threads
 )
 
 func getFilesSubRoutes(r *mux.Router) {
@@ -46,10 +51,16 @@ func getFilesSubRoutes(r *mux.Router) {
 	// + POST /file/{id}/comment - Post a new comment
 	// + POST /file/{id}/comment/{commentId}/edit - Edit an existing comment
 	// + POST /file/{id}/comment/{commentId}/delete - Delete an existing comment
+	// + POST /file/{id}/comment/{commentId}/resolve - Resolve a comment thread (in comments.go)
+	// + POST /file/{id}/comment/{commentId}/reopen - Reopen a resolved comment thread (in comments.go)
+	// + POST /file/{id}/comment/{commentId}/react - Toggle a reaction to a comment (in comments.go)
 	files.HandleFunc("/{id}", GetFile).Methods(http.MethodGet)
 	files.HandleFunc("/{id}"+ENDPOINT_COMMENT, PostUploadUserComment).Methods(http.MethodPost, http.MethodOptions)
 	files.HandleFunc("/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_EDIT, PostEditUserComment).Methods(http.MethodPost, http.MethodOptions)
 	files.HandleFunc("/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_DELETE, PostDeleteUserComment).Methods(http.MethodPost, http.MethodOptions)
+	files.HandleFunc("/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_RESOLVE, PostResolveComment).Methods(http.MethodPost, http.MethodOptions)
+	files.HandleFunc("/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_REOPEN, PostReopenComment).Methods(http.MethodPost, http.MethodOptions)
+	files.HandleFunc("/{id}"+ENDPOINT_COMMENT+"/{commentId}"+ENDPOINT_REACT, PostReactComment).Methods(http.MethodPost, http.MethodOptions)
 }
 
 // Get the path to the submissions directory. (defined anonymously to allow for mocking in the tests)
@@ -61,8 +72,11 @@ var getSubmissionDirectoryPath = func(s Submission) string {
 // Router functions
 // -----
 
-// Returns file with comments and metadata.
-// GET /file/{id}
+// Returns file with comments and metadata, and the number of open comment
+// threads of each file of its submission. Resolved threads are hidden unless
+// resolved=true is given. Threads are paginated if a page size is given, the
+// first page by default
+// GET /file/{id}?resolved=true&page=1&pageSize=20
 func GetFile(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	var err error
@@ -75,8 +89,12 @@ func GetFile(w http.ResponseWriter, r *http.Request) {
 		resp.StandardResponse = StandardResponse{Message: "Bad Request - file ID unable to be parsed", Error: true}
 		w.WriteHeader(http.StatusBadRequest)
 
+	} else if threads, err := getCommentThreadQuery(r.URL.Query()); err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Bad Request - " + err.Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
 		// calls helper function to get the file struct for the given ID
-	} else if resp.File, err = getFileData(uint(fileID64)); err != nil {
+	} else if resp.File, resp.Threads, err = getFileData(uint(fileID64), threads); err != nil {
 		switch err.(type) {
 		case *FileNotFoundError:
 			resp.StandardResponse = StandardResponse{Message: "Bad Request - no file exists for the given ID", Error: true}
@@ -88,6 +106,25 @@ func GetFile(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// counts the open threads of the submission's files
+	if resp.File != nil {
+		if resp.UnresolvedThreads, err = getUnresolvedThreads(resp.File.SubmissionID); err != nil {
+			resp = &GetFileResponse{StandardResponse: StandardResponse{Message: "Internal Server Error - undisclosed", Error: true}}
+			log.Printf("[ERROR] unable to count unresolved comment threads: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// hides identities the user cannot see under blind review
+	if resp.File != nil {
+		ctx, _ := r.Context().Value("data").(*RequestContext)
+		if err := blindFile(resp.File, ctx); err != nil {
+			resp = &GetFileResponse{StandardResponse: StandardResponse{Message: "Internal Server Error - undisclosed", Error: true}}
+			log.Printf("[ERROR] unable to apply blind review to file: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
 	// Encode response - set as error if empty
 	if err := json.NewEncoder(w).Encode(resp); err != nil {
 		log.Printf("[ERROR] JSON repsonse formatting failed: %v", err)
@@ -99,6 +136,24 @@ func GetFile(w http.ResponseWriter, r *http.Request) {
 // Helper Functions
 // -----
 
+// reads the comment threads to get from the query parameters of a GET /file/{id} request
+func getCommentThreadQuery(queryParams url.Values) (*CommentThreadQuery, error) {
+	threads := &CommentThreadQuery{Resolved: queryParams.Get("resolved") == "true", Page: 1}
+	var err error
+	if page := queryParams.Get("page"); page != "" {
+		if threads.Page, err = strconv.Atoi(page); err != nil || threads.Page < 1 {
+			return nil, &BadQueryParameterError{ParamName: "page", Value: page}
+		}
+	}
+	if pageSize := queryParams.Get("pageSize"); pageSize != "" {
+		if threads.PageSize, err = strconv.Atoi(pageSize); err != nil ||
+			threads.PageSize < 0 || threads.PageSize > MAX_THREAD_PAGE_SIZE {
+			return nil, &BadQueryParameterError{ParamName: "pageSize", Value: pageSize}
+		}
+	}
+	return threads, nil
+}
+
 // Add file to submission, and store it in filesystem and database
 // Note: Need valid submission. No comments exist on file
 // creation.
@@ -115,9 +170,17 @@ func addFileTo(file *File, submissionID uint) (uint, error) {
 	submission := &Submission{}
 	if err := gormDb.Transaction(func(tx *gorm.DB) error {
 		// queries the submission name (for use in accessing the filesystem)
-		if err := tx.Select("Name, created_at, ID").First(submission, submissionID).Error; err != nil {
+		if err := tx.Select("Name, created_at, ID, version").First(submission, submissionID).Error; err != nil {
 			return err
 		}
+		// the file belongs to the current version of the submission
+		file.Version = submission.Version
+		// classifies the file and applies the journal's file policy to it
+		files := []File{*file}
+		if err := applyFilePolicy(tx, files); err != nil {
+			return err
+		}
+		*file = files[0]
 		// adds a file to the submission in the db provided the submission exists
 		if err := tx.Model(submission).Association("Files").Append(file); err != nil {
 			return err
@@ -141,48 +204,52 @@ func addFileTo(file *File, submissionID uint) (uint, error) {
 	return file.ID, nil
 }
 
-// helper function to return a file object given its ID
+// helper function to return a file object given its ID, with its comment
+// threads. All of the file's comments are queried at once and built into
+// threads in memory.
 //
 // Params:
 // 	fileID (int) : the file's unique id
+// 	threads (*CommentThreadQuery) : the comment threads to return, nil for every thread
 // Returns:
 //	(*File) : the a file struct corresponding to the given ID
+// 	(int) : the number of threads matching the query across all pages
 // 	(error) : an error if something goes wrong
-func getFileData(fileID uint) (*File, error) {
+func getFileData(fileID uint, threads *CommentThreadQuery) (*File, int, error) {
+	if threads == nil {
+		threads = &CommentThreadQuery{Resolved: true}
+	}
 	submission := &Submission{}
 	file := &File{}
+	threadCount := 0
 	if err := gormDb.Transaction(func(tx *gorm.DB) error {
 		// queries the file from the database
 		file.ID = fileID
-		if res := gormDb.Model(file).Find(file); res.Error != nil {
+		if res := tx.Model(file).Find(file); res.Error != nil {
 			return res.Error
 		} else if res.RowsAffected == 0 {
 			return &FileNotFoundError{ID: fileID}
 		}
-		// gets the file comments ordered by newest.
+		// gets all of the file's comments, replies included, and builds their threads ordered by newest
 		var comments []Comment
-		if err := gormDb.Model(&Comment{}).Order("created_at desc").
-			Preload("Comments").Where("comments.parent_id IS NULL").
+		if err := tx.Model(&Comment{}).Preload("Reactions").Preload("Mentions").Order("id").
 			Find(&comments, "file_id = ?", fileID).Error; err != nil {
 			return err
 		}
-		var err error
-		fileComments := []Comment{}
-		for _, comment := range comments {
-			if comment.Comments, err = loadComments(tx, &comment); err != nil {
-				return err
-			}
-			fileComments = append(fileComments, comment)
+		fileComments := buildCommentTree(comments, MAX_COMMENT_DEPTH)
+		if !threads.Resolved {
+			fileComments = hideResolvedThreads(fileComments)
 		}
-		file.Comments = fileComments
+		threadCount = len(fileComments)
+		file.Comments = pageThreads(fileComments, threads.Page, threads.PageSize)
 
 		// queries the submission name
-		if err := gormDb.Select("Name, ID, created_at").Find(submission, file.SubmissionID).Error; err != nil {
+		if err := tx.Select("Name, ID, created_at").Find(submission, file.SubmissionID).Error; err != nil {
 			return err
 		}
 		return nil
 	}); err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
 	// builds path to the file using the queried submission name
@@ -192,36 +259,70 @@ func getFileData(fileID uint) (*File, error) {
 	var err error
 	file.Base64Value, err = getFileContent(fullFilePath)
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return file, nil
+	return file, threadCount, nil
 }
 
-// Recursive functions for loading comment replies.
+// Builds a file's comments into threads: the comments starting threads, newest
+// first, with their replies nested under them, oldest first. Replies nested
+// deeper than the depth limit are flattened into the deepest level, after the
+// reply they answer. Replies whose parent is not among the comments are left out.
 //
 // Params:
-// 	tx (*gorm.DB) - A gorm.DB instance to be query on (as this is used in transactions only)
-// 	c (Comment) - the comment to get the children of
+// 	comments ([]Comment) : the file's comments, ordered by ID
+// 	maxDepth (int) : the deepest level of replies, at least 1
 // Returns:
-// 	([]Commment) - c's list of child comments
-// 	(error) - an error if one occurs
-func loadComments(tx *gorm.DB, c *Comment) ([]Comment, error) {
-	// gets c's child comments as an array
-	var err error
-	childArray := []Comment{} // array of child comments
-	if err = tx.Model(&Comment{}).Where("parent_id = ?", c.ID).Find(&childArray).Error; err != nil {
-		return nil, err
-	}
-	// array of child comments with their nested comments to allow for memory re-alloc (so that nesting is preserved)
-	nestedChildArray := []Comment{}
-	for _, child := range childArray {
-		if child.Comments, err = loadComments(tx, &child); err != nil {
-			return nil, err
+// 	([]Comment) : the comments starting threads, with their replies set
+func buildCommentTree(comments []Comment, maxDepth int) []Comment {
+	roots := []int{}
+	replies := make(map[uint][]int)
+	for i, comment := range comments {
+		if comment.ParentID == nil {
+			roots = append(roots, i)
 		} else {
-			nestedChildArray = append(nestedChildArray, child)
+			replies[*comment.ParentID] = append(replies[*comment.ParentID], i)
+		}
+	}
+
+	// builds a comment with its replies, returning the replies too deep to nest after it
+	var build func(i int, depth int) []Comment
+	build = func(i int, depth int) []Comment {
+		comment := comments[i]
+		nested := []Comment{}
+		for _, reply := range replies[comment.ID] {
+			nested = append(nested, build(reply, depth+1)...)
+		}
+		if depth < maxDepth {
+			comment.Comments = nested
+			return []Comment{comment}
 		}
+		comment.Comments = []Comment{}
+		return append([]Comment{comment}, nested...)
+	}
+
+	threads := []Comment{}
+	for _, root := range roots {
+		threads = append(threads, build(root, 0)...)
+	}
+	sort.SliceStable(threads, func(i, j int) bool {
+		return threads[i].CreatedAt.After(threads[j].CreatedAt)
+	})
+	return threads
+}
+
+// gets a page of comment threads, numbered from 1. A page size of 0 gets every thread
+func pageThreads(threads []Comment, page int, pageSize int) []Comment {
+	if pageSize <= 0 {
+		return threads
+	}
+	start := (page - 1) * pageSize
+	if start >= len(threads) {
+		return []Comment{}
+	} else if start+pageSize > len(threads) {
+		return threads[start:]
 	}
-	return nestedChildArray, nil
+	return threads[start : start+pageSize]
 }
 
 // Get base64 encoded file content from filesystem.
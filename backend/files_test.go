@@ -14,11 +14,14 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
 )
 
 const (
@@ -50,7 +53,7 @@ func TestGetFile(t *testing.T) {
 	reviewerID := globalReviewers[0].ID
 
 	testSubmission.Files = []File{testFile}
-	submissionID, err := addSubmission(&testSubmission)
+	submissionID, err := addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "Error adding submission %s: %v", testSubmission.Name, err) {
 		return
 	}
@@ -159,7 +162,7 @@ func TestAddFile(t *testing.T) {
 	testSubmission.Authors = globalAuthors[:1]
 	testSubmission.Reviewers = globalReviewers[:1]
 
-	submissionID, err := addSubmission(&testSubmission) // adds a submission for the file to be uploaded to
+	submissionID, err := addSubmission(&testSubmission, EVENT_CREATED, "") // adds a submission for the file to be uploaded to
 	if !assert.NoErrorf(t, err, "Error occurred while adding test submission: %v", err) {
 		return
 	}
@@ -239,7 +242,7 @@ func TestGetFileData(t *testing.T) {
 	testSubmission.Reviewers = globalReviewers[:1]
 
 	// adds a submission to the database and filesystem
-	submissionID, err := addSubmission(&testSubmission)
+	submissionID, err := addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "Error adding submission %s: %v", testSubmission.Name, err) {
 		return
 	}
@@ -248,7 +251,7 @@ func TestGetFileData(t *testing.T) {
 	t.Run("Single Valid File", func(t *testing.T) {
 
 		// queries the file's data from it's ID
-		queriedFile, err := getFileData(testSubmission.Files[0].ID)
+		queriedFile, _, err := getFileData(testSubmission.Files[0].ID, nil)
 		if !assert.NoErrorf(t, err, "Should not error but got: %v", err) {
 			return
 		}
@@ -262,3 +265,82 @@ func TestGetFileData(t *testing.T) {
 		}
 	})
 }
+
+// tests that comments are built into threads newest first, with replies nested oldest first up to the depth limit
+func TestBuildCommentTree(t *testing.T) {
+	now := time.Now()
+	parent := func(id uint) *uint { return &id }
+	comment := func(id uint, parentID *uint, age time.Duration) Comment {
+		return Comment{Model: gorm.Model{ID: id, CreatedAt: now.Add(-age)}, ParentID: parentID}
+	}
+	// thread 1 has a chain of replies 3 -> 4 -> 5, thread 2 has a reply 6 and reply 7 has no parent left
+	comments := []Comment{
+		comment(1, nil, 2*time.Hour), comment(2, nil, time.Hour), comment(3, parent(1), 0),
+		comment(4, parent(3), 0), comment(5, parent(4), 0), comment(6, parent(2), 0), comment(7, parent(9), 0),
+	}
+
+	t.Run("Nested Replies", func(t *testing.T) {
+		threads := buildCommentTree(comments, MAX_COMMENT_DEPTH)
+		switch {
+		case !assert.Len(t, threads, 2, "wrong number of threads"),
+			!assert.Equal(t, uint(2), threads[0].ID, "newest thread not first"),
+			!assert.Len(t, threads[0].Comments, 1, "wrong number of replies"),
+			!assert.Equal(t, uint(6), threads[0].Comments[0].ID, "wrong reply"),
+			!assert.Equal(t, uint(1), threads[1].ID, "oldest thread not last"),
+			!assert.Equal(t, uint(3), threads[1].Comments[0].ID, "wrong reply"),
+			!assert.Equal(t, uint(4), threads[1].Comments[0].Comments[0].ID, "wrong nested reply"),
+			!assert.Equal(t, uint(5), threads[1].Comments[0].Comments[0].Comments[0].ID, "wrong nested reply"):
+			return
+		}
+	})
+
+	t.Run("Depth Limit", func(t *testing.T) {
+		threads := buildCommentTree(comments, 2)
+		if !assert.Len(t, threads, 2, "wrong number of threads") {
+			return
+		}
+		replies := threads[1].Comments[0].Comments
+		switch {
+		case !assert.Len(t, replies, 2, "deep reply not flattened"),
+			!assert.Equal(t, uint(4), replies[0].ID, "wrong reply at the depth limit"),
+			!assert.Equal(t, uint(5), replies[1].ID, "flattened reply not after the reply it answers"),
+			!assert.Empty(t, replies[0].Comments, "replies nested past the depth limit"):
+			return
+		}
+	})
+}
+
+// tests that threads are paginated, every thread being returned without a page size
+func TestPageThreads(t *testing.T) {
+	threads := make([]Comment, 5)
+	for i := range threads {
+		threads[i].ID = uint(i + 1)
+	}
+	switch {
+	case !assert.Len(t, pageThreads(threads, 1, 0), 5, "threads paginated without a page size"),
+		!assert.Equal(t, threads[:2], pageThreads(threads, 1, 2), "wrong first page"),
+		!assert.Equal(t, threads[4:], pageThreads(threads, 3, 2), "wrong last page"),
+		!assert.Empty(t, pageThreads(threads, 4, 2), "threads past the last page"):
+		return
+	}
+}
+
+// tests that the thread query parameters are read and validated
+func TestGetCommentThreadQuery(t *testing.T) {
+	threads, err := getCommentThreadQuery(url.Values{})
+	switch {
+	case !assert.NoError(t, err, "error reading empty query"),
+		!assert.Equal(t, &CommentThreadQuery{Page: 1}, threads, "wrong default query"):
+		return
+	}
+	threads, err = getCommentThreadQuery(url.Values{"resolved": {"true"}, "page": {"2"}, "pageSize": {"20"}})
+	switch {
+	case !assert.NoError(t, err, "error reading query"),
+		!assert.Equal(t, &CommentThreadQuery{Resolved: true, Page: 2, PageSize: 20}, threads, "wrong query"):
+		return
+	}
+	for _, query := range []url.Values{{"page": {"0"}}, {"page": {"first"}}, {"pageSize": {"-1"}}, {"pageSize": {"101"}}} {
+		_, err := getCommentThreadQuery(query)
+		assert.IsType(t, &BadQueryParameterError{}, err, "no error for invalid query %v", query)
+	}
+}
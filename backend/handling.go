@@ -0,0 +1,310 @@
+// =========================================================================
+// handling.go
+// Created: October 19, 2026
+//
+// This file takes care of handling editors: the editor in charge of a
+// submission, who alone makes its decisions and assigns its reviewers.
+// Chief editors can act on every submission and reassign handling editors.
+// Submissions without a handling editor can be handled by any editor
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	ENDPOINT_HANDLING_EDITOR = "/handlingeditor"
+	ENDPOINT_CHIEF_EDITOR    = "/chiefeditor"
+
+	HANDLING_EDITOR_NONE = "none" // filters submissions without a handling editor
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to assign or reassign the handling editor of a
+// submission. Editors can take unhandled submissions, handling editors can
+// hand theirs over, and chief editors can reassign any submission
+// POST /submission/{id}/handlingeditor
+func PostAssignHandlingEditor(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Handling editor assigned successfully", Error: false}
+	reqBody := &AssignHandlingEditorBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to assign handling editors.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerAssignHandlingEditor(submissionID, reqBody.Editor, ctx.ID); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *NotHandlingEditorError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case *BadUserError, *WrongPermissionsError:
+			resp = &StandardResponse{Message: "The handling editor must be a registered editor.", Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			log.Printf("[ERROR] could not assign handling editor: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not assign handling editor", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function to grant or revoke an editor's chief editor role. Only
+// chief editors can do so. The first chief editor is appointed from the
+// command line (see appointChiefEditor)
+// POST /user/{id}/chiefeditor
+func PostSetChiefEditor(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Chief editor role updated successfully", Error: false}
+	reqBody := &SetChiefEditorBody{}
+
+	params := mux.Vars(r)
+	userID := params["id"]
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to appoint chief editors.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerSetChiefEditor(userID, reqBody.ChiefEditor, ctx.ID); err != nil {
+		switch err.(type) {
+		case *BadUserError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp = &StandardResponse{Message: "Only chief editors can appoint chief editors, who must be editors.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case *LastChiefEditorError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			log.Printf("[ERROR] could not update chief editor role: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not update chief editor role", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which assigns the handling editor of a submission, replacing the
+// current one if there is one.
+//
+// Params:
+// 	submissionID (uint) : the submission
+// 	handlingEditorID (string) : the editor to put in charge of the submission
+// 	editorID (string) : the editor making the assignment
+// Returns:
+// 	(error) : a *NotHandlingEditorError if the editor cannot reassign the submission, another error if one occurs
+func ControllerAssignHandlingEditor(submissionID uint, handlingEditorID string, editorID string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		} else if err := checkHandlingEditor(tx, submission, editorID); err != nil {
+			return err
+		}
+		editor := &GlobalUser{}
+		if res := tx.Limit(1).Find(editor, "id = ?", handlingEditorID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &BadUserError{userID: handlingEditorID}
+		} else if editor.UserType != USERTYPE_EDITOR {
+			return &WrongPermissionsError{userID: handlingEditorID}
+		}
+
+		details := map[string]string{"editor": handlingEditorID}
+		if submission.HandlingEditorID != nil {
+			details["previous"] = *submission.HandlingEditorID
+		}
+		if err := tx.Model(&Submission{}).Where("id = ?", submissionID).
+			Update("handling_editor_id", handlingEditorID).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, submissionID, EVENT_HANDLING_EDITOR_ASSIGNED, editorID, true, details)
+	})
+}
+
+// Controller which grants or revokes an editor's chief editor role. The
+// chief editors are locked so that concurrent changes cannot leave the
+// journal without one.
+//
+// Params:
+// 	userID (string) : the editor
+// 	chiefEditor (bool) : true to grant the role, false to revoke it
+// 	editorID (string) : the chief editor making the change
+// Returns:
+// 	(error) : a *WrongPermissionsError if the change is not allowed, a *LastChiefEditorError
+// 		if it revokes the last chief editor, another error if one occurs
+func ControllerSetChiefEditor(userID string, chiefEditor bool, editorID string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		chiefEditors := []string{}
+		if err := tx.Model(&GlobalUser{}).Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("chief_editor = ?", true).Pluck("id", &chiefEditors).Error; err != nil {
+			return err
+		} else if !isIDInList(editorID, chiefEditors) {
+			return &WrongPermissionsError{userID: editorID}
+		}
+		user := &GlobalUser{}
+		if res := tx.Limit(1).Find(user, "id = ?", userID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &BadUserError{userID: userID}
+		} else if chiefEditor && user.UserType != USERTYPE_EDITOR {
+			return &WrongPermissionsError{userID: userID}
+		} else if !chiefEditor && user.ChiefEditor && len(chiefEditors) == 1 {
+			return &LastChiefEditorError{UserID: userID}
+		}
+		return tx.Model(user).Update("chief_editor", chiefEditor).Error
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Filters submissions by handling editor. "none" selects the submissions
+// without a handling editor.
+//
+// Params:
+// 	tx (*gorm.DB) : the submissions query
+// 	handlingEditors ([]string) : the handling editors' IDs, no filter if empty
+// Returns:
+// 	(*gorm.DB) : the filtered query
+func filterByHandlingEditor(tx *gorm.DB, handlingEditors []string) *gorm.DB {
+	if len(handlingEditors) == 0 {
+		return tx
+	}
+	editorIDs, unhandled := splitHandlingEditors(handlingEditors)
+	switch {
+	case len(editorIDs) > 0 && unhandled:
+		return tx.Where("(submissions.handling_editor_id IN ? OR submissions.handling_editor_id IS NULL)", editorIDs)
+	case unhandled:
+		return tx.Where("submissions.handling_editor_id IS NULL")
+	default:
+		return tx.Where("submissions.handling_editor_id IN ?", editorIDs)
+	}
+}
+
+// separates editor IDs from "none" in a handling editor filter
+func splitHandlingEditors(handlingEditors []string) ([]string, bool) {
+	editorIDs := []string{}
+	unhandled := false
+	for _, editorID := range handlingEditors {
+		if editorID == HANDLING_EDITOR_NONE {
+			unhandled = true
+		} else {
+			editorIDs = append(editorIDs, editorID)
+		}
+	}
+	return editorIDs, unhandled
+}
+
+// Appoints an editor as chief editor from the command line, which is how the
+// journal's first chief editor is appointed.
+//
+// Params:
+// 	db (*gorm.DB) : the db instance to update the editor on
+// 	userID (string) : the editor
+// Returns:
+// 	(error) : a *BadUserError if the user does not exist, a *WrongPermissionsError if they are not an editor
+func appointChiefEditor(db *gorm.DB, userID string) error {
+	user := &GlobalUser{}
+	if res := db.Limit(1).Find(user, "id = ?", userID); res.Error != nil {
+		return res.Error
+	} else if res.RowsAffected == 0 {
+		return &BadUserError{userID: userID}
+	} else if user.UserType != USERTYPE_EDITOR {
+		return &WrongPermissionsError{userID: userID}
+	}
+	return db.Model(user).Update("chief_editor", true).Error
+}
+
+// checks whether an ID is in a list of IDs
+func isIDInList(id string, ids []string) bool {
+	for _, listID := range ids {
+		if listID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// checks whether a user is a chief editor
+func isChiefEditor(tx *gorm.DB, userID string) (bool, error) {
+	res := tx.Select("id").Where("id = ? AND user_type = ? AND chief_editor = ?", userID, USERTYPE_EDITOR, true).
+		Limit(1).Find(&GlobalUser{})
+	return res.RowsAffected > 0, res.Error
+}
+
+// Checks that an editor can make decisions on and assign reviewers to a
+// submission: it has no handling editor, the editor is its handling editor,
+// or the editor is a chief editor.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submission (*Submission) : the submission, with its handling editor set
+// 	editorID (string) : the editor
+// Returns:
+// 	(error) : a *NotHandlingEditorError if the editor cannot act on the submission, another error if one occurs
+func checkHandlingEditor(tx *gorm.DB, submission *Submission, editorID string) error {
+	if isHandlingEditor(submission, editorID) {
+		return nil
+	} else if isChief, err := isChiefEditor(tx, editorID); err != nil {
+		return err
+	} else if !isChief {
+		return &NotHandlingEditorError{EditorID: editorID, SubmissionID: submission.ID}
+	}
+	return nil
+}
+
+// checks whether an editor handles a submission, which every editor does if it has no handling editor
+func isHandlingEditor(submission *Submission, editorID string) bool {
+	return submission.HandlingEditorID == nil || *submission.HandlingEditorID == editorID
+}
@@ -0,0 +1,55 @@
+// =====================================
+// handling_test.go
+// Created: October 19, 2026
+//
+// test file for handling.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that only the handling editor handles a submission, unless it has none
+func TestIsHandlingEditor(t *testing.T) {
+	handlingEditor := "editor1"
+	testCases := []struct {
+		name           string
+		handlingEditor *string
+		editor         string
+		handles        bool
+	}{
+		{"no handling editor", nil, "editor2", true},
+		{"handling editor", &handlingEditor, "editor1", true},
+		{"other editor", &handlingEditor, "editor2", false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			submission := &Submission{HandlingEditorID: testCase.handlingEditor}
+			assert.Equal(t, testCase.handles, isHandlingEditor(submission, testCase.editor), "wrong handling editor check")
+		})
+	}
+}
+
+// tests that "none" is separated from the editors of a handling editor filter
+func TestSplitHandlingEditors(t *testing.T) {
+	editorIDs, unhandled := splitHandlingEditors([]string{"editor1", HANDLING_EDITOR_NONE, "editor2"})
+	switch {
+	case !assert.Equal(t, []string{"editor1", "editor2"}, editorIDs, "wrong editors"),
+		!assert.True(t, unhandled, "unhandled submissions not selected"):
+		return
+	}
+	editorIDs, unhandled = splitHandlingEditors([]string{"editor1"})
+	switch {
+	case !assert.Equal(t, []string{"editor1"}, editorIDs, "wrong editors"),
+		!assert.False(t, unhandled, "unhandled submissions selected"):
+		return
+	}
+}
@@ -0,0 +1,186 @@
+// =========================================================================
+// history.go
+// Created: October 19, 2026
+//
+// This file takes care of the history of submissions: an append-only log of
+// the events in a submission's lifecycle (i.e. reviewers being assigned or
+// the submission being accepted), with who caused them and when
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_HISTORY = "/history"
+
+	// types of submission events
+	EVENT_CREATED                  = "created"                  // a submission was uploaded
+	EVENT_IMPORTED                 = "imported"                 // a submission was imported from another journal
+	EVENT_EXPORTED                 = "exported"                 // a submission was exported to another journal
+	EVENT_EDITED                   = "edited"                   // a submission's version or review mode was changed
+	EVENT_STATUS_CHANGED           = "status_changed"           // a submission moved through the review workflow
+	EVENT_REVIEWER_ASSIGNED        = "reviewer_assigned"        // a reviewer was assigned (and invited)
+	EVENT_REVIEWER_UNASSIGNED      = "reviewer_unassigned"      // a reviewer was unassigned by an editor
+	EVENT_INVITATION_ANSWERED      = "invitation_answered"      // a reviewer accepted or declined their invitation
+	EVENT_REVIEW_SUBMITTED         = "review_submitted"         // a reviewer uploaded a review
+	EVENT_REVIEW_EDITED            = "review_edited"            // a reviewer edited their review
+	EVENT_REVIEW_WITHDRAWN         = "review_withdrawn"         // a reviewer withdrew their review
+	EVENT_APPEAL_FILED             = "appeal_filed"             // an author appealed a rejection
+	EVENT_APPEAL_RESOLVED          = "appeal_resolved"          // an editor upheld the rejection or reopened the submission
+	EVENT_HANDLING_EDITOR_ASSIGNED = "handling_editor_assigned" // an editor was put in charge of a submission
+)
+
+// events are never changed or removed once recorded
+var errAppendOnlyEvent = errors.New("submission events are append-only")
+
+// ------
+// Router Functions
+// ------
+
+// router function to get the history of a submission. Editors see every event,
+// authors and reviewers only the events shared with them, without the
+// identities hidden from them under blind review
+// GET /submission/{id}/history
+func GetSubmissionHistory(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionHistoryResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if resp.Events, err = getSubmissionHistory(submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Only editors, authors and reviewers can view a submission's history.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] could not get submission history: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get submission history", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Records an event in a submission's history.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to record the event on (may be a transaction)
+// 	submissionID (uint) : the submission the event happened to
+// 	eventType (string) : the type of the event
+// 	actorID (string) : the user causing the event, empty if no user caused it
+// 	editorsOnly (bool) : true if only editors can see the event
+// 	details (map[string]string) : the details of the event, may be nil
+// Returns:
+// 	(error) : an error if one occurs
+func recordEvent(tx *gorm.DB, submissionID uint, eventType string, actorID string, editorsOnly bool, details map[string]string) error {
+	payload, err := json.Marshal(details)
+	if err != nil {
+		return err
+	}
+	return tx.Create(&SubmissionEvent{
+		SubmissionID: submissionID,
+		Type:         eventType,
+		ActorID:      actorID,
+		Payload:      string(payload),
+		EditorsOnly:  editorsOnly,
+	}).Error
+}
+
+// Gets the events of a submission's history a user can see, oldest first.
+//
+// Params:
+// 	submissionID (uint) : the submission
+// 	ctx (*RequestContext) : the logged in user
+// Returns:
+// 	([]SubmissionEvent) : the events, with their details set
+// 	(error) : a *WrongPermissionsError if the user cannot see the history, another error if one occurs
+func getSubmissionHistory(submissionID uint, ctx *RequestContext) ([]SubmissionEvent, error) {
+	submission := &Submission{}
+	if res := gormDb.Preload("Authors").Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoSubmissionError{ID: submissionID}
+	}
+	isEditor := ctx.UserType == USERTYPE_EDITOR
+	if !isEditor && !isUserInList(ctx.ID, submission.Authors) && !isUserInList(ctx.ID, submission.Reviewers) {
+		return nil, &WrongPermissionsError{userID: ctx.ID}
+	}
+	mode, err := getReviewMode(gormDb, submission)
+	if err != nil {
+		return nil, err
+	}
+
+	events := []SubmissionEvent{}
+	query := gormDb.Where("submission_id = ?", submissionID)
+	if !isEditor {
+		query = query.Where("editors_only = ?", false)
+	}
+	if err := query.Order("id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	for i := range events {
+		if err := json.Unmarshal([]byte(events[i].Payload), &events[i].Details); err != nil {
+			return nil, err
+		}
+	}
+	aliases, err := getSubmissionAliases(gormDb, submission, mode, ctx)
+	if err != nil {
+		return nil, err
+	}
+	blindEvents(events, aliases)
+	return events, nil
+}
+
+// replaces the identities the aliases are given for in events' actors and details
+func blindEvents(events []SubmissionEvent, aliases map[string]string) {
+	for i := range events {
+		if alias, ok := aliases[events[i].ActorID]; ok {
+			events[i].ActorID = alias
+		}
+		for key, value := range events[i].Details {
+			if alias, ok := aliases[value]; ok {
+				events[i].Details[key] = alias
+			}
+		}
+	}
+}
+
+// prevents events from being changed once recorded
+func (e *SubmissionEvent) BeforeUpdate(tx *gorm.DB) error {
+	return errAppendOnlyEvent
+}
+
+// prevents events from being removed once recorded
+func (e *SubmissionEvent) BeforeDelete(tx *gorm.DB) error {
+	return errAppendOnlyEvent
+}
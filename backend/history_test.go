@@ -0,0 +1,39 @@
+// =====================================
+// history_test.go
+// Created: October 19, 2026
+//
+// test file for history.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that hidden identities are replaced in events' actors and details
+func TestBlindEvents(t *testing.T) {
+	events := []SubmissionEvent{
+		{Type: EVENT_CREATED, ActorID: "author", Details: map[string]string{"name": "Submission"}},
+		{Type: EVENT_REVIEWER_ASSIGNED, ActorID: "editor", Details: map[string]string{"reviewer": "reviewer"}},
+		{Type: EVENT_REVIEW_SUBMITTED, ActorID: "reviewer", Details: map[string]string{"version": "1"}},
+		{Type: EVENT_IMPORTED},
+	}
+	blindEvents(events, map[string]string{"reviewer": "Reviewer 1"})
+	switch {
+	case !assert.Equal(t, "author", events[0].ActorID, "visible actor aliased"),
+		!assert.Equal(t, "Submission", events[0].Details["name"], "details changed"),
+		!assert.Equal(t, "editor", events[1].ActorID, "visible actor aliased"),
+		!assert.Equal(t, "Reviewer 1", events[1].Details["reviewer"], "hidden reviewer kept in details"),
+		!assert.Equal(t, "Reviewer 1", events[2].ActorID, "hidden actor kept"),
+		!assert.Equal(t, "1", events[2].Details["version"], "details changed"),
+		!assert.Empty(t, events[3].ActorID, "missing actor aliased"):
+		return
+	}
+}
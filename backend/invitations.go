@@ -0,0 +1,461 @@
+// =========================================================================
+// invitations.go
+// Created: October 19, 2026
+//
+// This file takes care of review invitations: reviewers assigned to a
+// submission are invited to review it by a due date, and accept or decline.
+// A scheduler reminds reviewers before their deadline, marks late reviews as
+// overdue and expires invitations which are not accepted in time
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_ACCEPT_INVITATION  = "/invitation/accept"
+	ENDPOINT_DECLINE_INVITATION = "/invitation/decline"
+	ENDPOINT_INVITATIONS        = "/invitations"
+	ENDPOINT_OVERDUE_REVIEWS    = "/overdue"
+	ENDPOINT_ASSIGNMENTS        = "/assignments"
+
+	// states of a review invitation
+	INVITATION_INVITED  = "invited"
+	INVITATION_ACCEPTED = "accepted"
+	INVITATION_DECLINED = "declined"
+	INVITATION_EXPIRED  = "expired"
+	INVITATION_REMOVED  = "removed" // the reviewer was unassigned by an editor
+
+	REVIEW_SCHEDULER_INTERVAL = time.Hour // how often invitations are checked for deadlines
+
+	// actions taken by the scheduler on an invitation
+	invitationNoAction = ""
+	invitationExpire   = "expire"
+	invitationOverdue  = "overdue"
+	invitationRemind   = "remind"
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for reviewers to accept an invitation to review a submission
+// POST /submission/{id}/invitation/accept
+func PostAcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Invitation accepted successfully", Error: false}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := ControllerRespondInvitation(submissionID, ctx.ID, true, ""); err != nil {
+		resp = invitationErrorResponse(w, err)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for reviewers to decline an invitation to review a submission
+// POST /submission/{id}/invitation/decline
+func PostDeclineInvitation(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Invitation declined successfully", Error: false}
+	reqBody := &DeclineInvitationBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerRespondInvitation(submissionID, ctx.ID, false, reqBody.Reason); err != nil {
+		resp = invitationErrorResponse(w, err)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to get every invitation sent for a submission,
+// including declined and expired ones, and the reviewers they unassigned
+// GET /submission/{id}/invitations
+func GetSubmissionInvitations(w http.ResponseWriter, r *http.Request) {
+	resp := &GetInvitationsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view review invitations.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Where("submission_id = ?", submissionID).Order("created_at").Find(&resp.Invitations).Error; err != nil {
+		log.Printf("[ERROR] could not get review invitations: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get review invitations", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if err := gormDb.Where("submission_id = ?", submissionID).Order("created_at").Find(&resp.Removals).Error; err != nil {
+		log.Printf("[ERROR] could not get reviewer removals: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get review invitations", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to get the accepted reviews which are past their deadline
+// GET /submissions/overdue
+func GetOverdueReviews(w http.ResponseWriter, r *http.Request) {
+	resp := &GetInvitationsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view overdue reviews.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Where("status = ? AND overdue = ? AND completed_at IS NULL", INVITATION_ACCEPTED, true).
+		Order("due_at").Find(&resp.Invitations).Error; err != nil {
+		log.Printf("[ERROR] could not get overdue reviews: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get overdue reviews", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for reviewers to get their pending invitations and accepted
+// assignments, with their deadlines
+// GET /submissions/assignments
+func GetReviewerAssignments(w http.ResponseWriter, r *http.Request) {
+	resp := &GetInvitationsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Where("reviewer_id = ? AND status IN ?", ctx.ID, []string{INVITATION_INVITED, INVITATION_ACCEPTED}).
+		Order("due_at").Find(&resp.Invitations).Error; err != nil {
+		log.Printf("[ERROR] could not get review assignments: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get review assignments", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which accepts or declines a reviewer's pending invitation to
+// review a submission. Declining removes the reviewer from the submission.
+//
+// Params:
+// 	submissionID (uint) : the submission the reviewer is invited to review
+// 	reviewerID (string) : the invited reviewer
+// 	accept (bool) : true to accept the invitation, false to decline it
+// 	reason (string) : the reason the invitation is declined
+// Returns:
+// 	(error) : a *NoInvitationError if the reviewer has no pending invitation, another error if one occurs
+func ControllerRespondInvitation(submissionID uint, reviewerID string, accept bool, reason string) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		invitation := &ReviewInvitation{}
+		if res := tx.Where("submission_id = ? AND reviewer_id = ? AND status = ?", submissionID, reviewerID, INVITATION_INVITED).
+			Limit(1).Find(invitation); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoInvitationError{UserID: reviewerID, SubmissionID: submissionID}
+		}
+		respondedAt := time.Now()
+		invitation.RespondedAt = &respondedAt
+		if accept {
+			invitation.Status = INVITATION_ACCEPTED
+		} else {
+			invitation.Status = INVITATION_DECLINED
+			invitation.DeclineReason = reason
+		}
+		if err := tx.Save(invitation).Error; err != nil {
+			return err
+		}
+		// only editors see declined invitations, as the reviewer is removed
+		if err := recordEvent(tx, submissionID, EVENT_INVITATION_ANSWERED, reviewerID, !accept,
+			map[string]string{"status": invitation.Status}); err != nil {
+			return err
+		} else if accept {
+			return nil
+		}
+		return removeReviewer(tx, submissionID, reviewerID)
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the response for errors responding to an invitation, setting the
+// matching status code
+func invitationErrorResponse(w http.ResponseWriter, err error) *StandardResponse {
+	switch err.(type) {
+	case *NoInvitationError:
+		w.WriteHeader(http.StatusNotFound)
+		return &StandardResponse{Message: err.Error(), Error: true}
+	default:
+		log.Printf("[ERROR] could not respond to review invitation: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return &StandardResponse{Message: "Internal Server Error - could not respond to review invitation", Error: true}
+	}
+}
+
+// Invites reviewers newly assigned to a submission to review it.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	reviewerIDs ([]string) : the newly assigned reviewers
+// 	submissionID (uint) : the submission they are assigned to
+// 	dueAt (time.Time) : the deadline of their reviews, the journal's review period from now if zero
+// Returns:
+// 	(error) : an error if one occurs
+func inviteReviewers(tx *gorm.DB, reviewerIDs []string, submissionID uint, dueAt time.Time) error {
+	if len(reviewerIDs) == 0 {
+		return nil
+	}
+	if dueAt.IsZero() {
+		settings, err := getJournalSettings(tx)
+		if err != nil {
+			return err
+		}
+		dueAt = time.Now().AddDate(0, 0, settings.ReviewPeriodDays)
+	}
+	invitations := make([]ReviewInvitation, len(reviewerIDs))
+	for i, reviewerID := range reviewerIDs {
+		invitations[i] = ReviewInvitation{
+			SubmissionID: submissionID,
+			ReviewerID:   reviewerID,
+			Status:       INVITATION_INVITED,
+			DueAt:        dueAt,
+		}
+	}
+	return tx.Create(&invitations).Error
+}
+
+// removes a reviewer from a submission's reviewers
+func removeReviewer(tx *gorm.DB, submissionID uint, reviewerID string) error {
+	submission := &Submission{}
+	submission.ID = submissionID
+	return tx.Model(submission).Association("Reviewers").Delete(&GlobalUser{ID: reviewerID})
+}
+
+// Records whether a reviewer's review of the current version of a submission
+// is done. Uploading a review accepts the reviewer's pending invitation, and
+// withdrawing it reopens the assignment.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submissionID (uint) : the reviewed submission
+// 	reviewerID (string) : the reviewer
+// 	completed (bool) : true if the review was uploaded, false if it was withdrawn
+// Returns:
+// 	(error) : an error if one occurs
+func setReviewCompleted(tx *gorm.DB, submissionID uint, reviewerID string, completed bool) error {
+	invitation := &ReviewInvitation{}
+	if res := tx.Where("submission_id = ? AND reviewer_id = ? AND status IN ?", submissionID, reviewerID,
+		[]string{INVITATION_INVITED, INVITATION_ACCEPTED}).Limit(1).Find(invitation); res.Error != nil {
+		return res.Error
+	} else if res.RowsAffected == 0 {
+		return nil // reviewers assigned before invitations have none
+	}
+	invitation.CompletedAt = nil
+	if completed {
+		now := time.Now()
+		if invitation.Status == INVITATION_INVITED {
+			invitation.Status = INVITATION_ACCEPTED
+			invitation.RespondedAt = &now
+		}
+		invitation.CompletedAt = &now
+		invitation.Overdue = false
+	}
+	return tx.Save(invitation).Error
+}
+
+// gives the accepted reviewers of a resubmitted or reopened submission a new
+// deadline to review it again
+func resetReviewDeadlines(tx *gorm.DB, submissionID uint) error {
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return err
+	}
+	return tx.Model(&ReviewInvitation{}).Where("submission_id = ? AND status = ?", submissionID, INVITATION_ACCEPTED).
+		Updates(map[string]interface{}{
+			"due_at":       time.Now().AddDate(0, 0, settings.ReviewPeriodDays),
+			"completed_at": nil,
+			"reminded_at":  nil,
+			"overdue":      false,
+		}).Error
+}
+
+// runs the review scheduler at a regular interval, until the server stops
+func runReviewScheduler(db *gorm.DB, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := processInvitations(db, time.Now()); err != nil {
+			log.Printf("[ERROR] could not process review invitations: %v\n", err)
+		}
+		<-ticker.C
+	}
+}
+
+// Expires, marks as overdue and sends reminders for the open invitations of
+// submissions which are not decided yet.
+//
+// Params:
+// 	db (*gorm.DB) : the db instance to query on
+// 	now (time.Time) : the time deadlines are checked against
+// Returns:
+// 	(error) : an error if one occurs
+func processInvitations(db *gorm.DB, now time.Time) error {
+	settings, err := getJournalSettings(db)
+	if err != nil {
+		return err
+	}
+	submissions := []Submission{}
+	if err := db.Select("id, status").Where("status NOT IN ?", []string{STATUS_DRAFT, STATUS_ACCEPTED, STATUS_REJECTED, STATUS_WITHDRAWN}).
+		Find(&submissions).Error; err != nil {
+		return err
+	}
+	statuses := make(map[uint]string)
+	submissionIDs := []uint{}
+	for _, submission := range submissions {
+		statuses[submission.ID] = submission.Status
+		submissionIDs = append(submissionIDs, submission.ID)
+	}
+	if len(submissionIDs) == 0 {
+		return nil
+	}
+
+	invitations := []ReviewInvitation{}
+	if err := db.Where("submission_id IN ? AND status IN ? AND completed_at IS NULL", submissionIDs,
+		[]string{INVITATION_INVITED, INVITATION_ACCEPTED}).Find(&invitations).Error; err != nil {
+		return err
+	}
+	// invitations are only updated if they are still open as read, so that
+	// reviewers accepting or reviewing in the meantime are not overwritten
+	for i := range invitations {
+		invitation := &invitations[i]
+		switch invitationAction(invitation, statuses[invitation.SubmissionID], settings, now) {
+		case invitationExpire:
+			if err := db.Transaction(func(tx *gorm.DB) error {
+				res := openInvitation(tx, invitation).Update("status", INVITATION_EXPIRED)
+				if res.Error != nil || res.RowsAffected == 0 {
+					return res.Error
+				}
+				return removeReviewer(tx, invitation.SubmissionID, invitation.ReviewerID)
+			}); err != nil {
+				return err
+			}
+		case invitationOverdue:
+			if err := openInvitation(db, invitation).Where("due_at < ?", now).Update("overdue", true).Error; err != nil {
+				return err
+			}
+		case invitationRemind:
+			if res := openInvitation(db, invitation).Where("reminded_at IS NULL").Update("reminded_at", now); res.Error != nil {
+				return res.Error
+			} else if res.RowsAffected > 0 {
+				sendReviewReminder(invitation)
+			}
+		}
+	}
+	return nil
+}
+
+// selects an invitation if it is still open with the status it was read with
+func openInvitation(tx *gorm.DB, invitation *ReviewInvitation) *gorm.DB {
+	return tx.Model(&ReviewInvitation{}).Where("id = ? AND status = ? AND completed_at IS NULL", invitation.ID, invitation.Status)
+}
+
+// Gets the action the scheduler takes on an open invitation: invitations
+// which are not accepted in time expire, and accepted reviews of submissions
+// under review get a reminder before their deadline and are overdue after it.
+//
+// Params:
+// 	invitation (*ReviewInvitation) : the invitation, invited or accepted and not completed
+// 	submissionStatus (string) : the status of the invitation's submission
+// 	settings (*JournalSettings) : the journal's settings
+// 	now (time.Time) : the time deadlines are checked against
+// Returns:
+// 	(string) : the action to take, invitationNoAction if there is none
+func invitationAction(invitation *ReviewInvitation, submissionStatus string, settings *JournalSettings, now time.Time) string {
+	if invitation.Status == INVITATION_INVITED {
+		if settings.InvitationExpiryDays > 0 && now.After(invitation.CreatedAt.AddDate(0, 0, settings.InvitationExpiryDays)) {
+			return invitationExpire
+		}
+		return invitationNoAction
+	} else if submissionStatus != STATUS_UNDER_REVIEW || invitation.Overdue {
+		return invitationNoAction
+	}
+
+	if now.After(invitation.DueAt) {
+		return invitationOverdue
+	} else if invitation.RemindedAt == nil && settings.ReminderDays > 0 &&
+		now.After(invitation.DueAt.AddDate(0, 0, -settings.ReminderDays)) {
+		return invitationRemind
+	}
+	return invitationNoAction
+}
+
+// reminds a reviewer that their review is due soon. The journal has no mail
+// service, so reminders are logged and shown to reviewers with their assignments
+func sendReviewReminder(invitation *ReviewInvitation) {
+	log.Printf("[INFO] reminding reviewer %s that their review of submission %d is due %s\n",
+		invitation.ReviewerID, invitation.SubmissionID, invitation.DueAt.Format(time.RFC3339))
+}
@@ -0,0 +1,58 @@
+// =====================================
+// invitations_test.go
+// Created: October 19, 2026
+//
+// test file for invitations.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests which invitations the scheduler expires, marks as overdue or reminds
+func TestInvitationAction(t *testing.T) {
+	now := time.Date(2026, time.October, 19, 12, 0, 0, 0, time.UTC)
+	settings := &JournalSettings{InvitationExpiryDays: 7, ReminderDays: 3}
+	invitedAt := func(days int) gorm.Model {
+		return gorm.Model{CreatedAt: now.AddDate(0, 0, -days)}
+	}
+	reminded := now.AddDate(0, 0, -1)
+
+	testCases := []struct {
+		name       string
+		invitation ReviewInvitation
+		status     string
+		settings   *JournalSettings
+		action     string
+	}{
+		{"pending invitation", ReviewInvitation{Model: invitedAt(2), Status: INVITATION_INVITED}, STATUS_SUBMITTED, settings, invitationNoAction},
+		{"expired invitation", ReviewInvitation{Model: invitedAt(8), Status: INVITATION_INVITED}, STATUS_UNDER_REVIEW, settings, invitationExpire},
+		{"expiry disabled", ReviewInvitation{Model: invitedAt(8), Status: INVITATION_INVITED}, STATUS_UNDER_REVIEW,
+			&JournalSettings{ReminderDays: 3}, invitationNoAction},
+		{"deadline far", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, 10)}, STATUS_UNDER_REVIEW, settings, invitationNoAction},
+		{"deadline close", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, 2)}, STATUS_UNDER_REVIEW, settings, invitationRemind},
+		{"already reminded", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, 2), RemindedAt: &reminded},
+			STATUS_UNDER_REVIEW, settings, invitationNoAction},
+		{"deadline passed", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, -1), RemindedAt: &reminded},
+			STATUS_UNDER_REVIEW, settings, invitationOverdue},
+		{"already overdue", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, -1), Overdue: true},
+			STATUS_UNDER_REVIEW, settings, invitationNoAction},
+		{"awaiting revisions", ReviewInvitation{Status: INVITATION_ACCEPTED, DueAt: now.AddDate(0, 0, -1)},
+			STATUS_REVISIONS_REQUESTED, settings, invitationNoAction},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.action, invitationAction(&testCase.invitation, testCase.status, testCase.settings, now), "wrong action")
+		})
+	}
+}
@@ -214,6 +214,10 @@ func PostExportSubmission(w http.ResponseWriter, r *http.Request) {
 			resp = &StandardResponse{Message: "Export Failed - error communicating with external server", Error: true}
 		} else {
 			resp = &StandardResponse{Message: "Export Success", Error: false}
+			if err := recordEvent(gormDb, submissionID, EVENT_EXPORTED, ctx.ID, false,
+				map[string]string{"groupNumber": strconv.Itoa(groupNumber)}); err != nil {
+				log.Printf("[ERROR] could not record submission export: %v\n", err)
+			}
 		}
 		w.WriteHeader(globalResp.StatusCode)
 	}
@@ -249,10 +253,10 @@ func PostImportSubmission(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 		}
 
-		// adds the local submission to the db
-		if submissionID, err := addSubmission(localSubmission); err != nil {
+		// adds the local submission to the db, imports are not caused by a local user
+		if submissionID, err := addSubmission(localSubmission, EVENT_IMPORTED, ""); err != nil {
 			switch err.(type) {
-			case *DuplicateFileError:
+			case *DuplicateFileError, *SecretsFoundError, *RejectedFileError:
 				resp = &StandardResponse{Message: err.Error(), Error: true}
 				w.WriteHeader(http.StatusBadRequest)
 
@@ -240,7 +240,7 @@ func TestExportSubmission(t *testing.T) {
 		},
 	}
 
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -426,7 +426,7 @@ func TestLocalToGlobal(t *testing.T) {
 	testSubmission.Reviewers = globalReviewers[:1]
 
 	testSubmission.Files = []File{testFile}
-	submissionID, err := addSubmission(testSubmission.getCopy())
+	submissionID, err := addSubmission(testSubmission.getCopy(), EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "Error occurred while adding submission: %v", err) {
 		return
 	}
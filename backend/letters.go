@@ -0,0 +1,378 @@
+// =========================================================================
+// letters.go
+// Created: October 19, 2026
+//
+// This file takes care of decision letters, written by editors to the authors
+// of a submission when accepting or rejecting it. Letters can start from
+// templates managed by the journal's editors, whose placeholders are filled in
+// with the submission's details
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_TEMPLATES       = "/templates"
+	ENDPOINT_CREATE_TEMPLATE = "/create"
+	ENDPOINT_LETTER          = "/letter"
+
+	// placeholders which can be used in decision letters
+	PLACEHOLDER_SUBMISSION_NAME    = "submission_name"    // the name of the submission
+	PLACEHOLDER_DECISION           = "decision"           // accepted or rejected
+	PLACEHOLDER_REVIEWER_SUMMARIES = "reviewer_summaries" // one line per review of the current version
+	PLACEHOLDER_DEADLINE           = "deadline"           // the deadline given with the decision
+
+	LETTER_DEADLINE_FORMAT = "January 2, 2006"
+)
+
+// placeholders are written as {{name}}
+var placeholderRegex = regexp.MustCompile(`\{\{([a-z_]+)\}\}`)
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to get the journal's decision letter templates
+// GET /settings/templates
+func GetLetterTemplates(w http.ResponseWriter, r *http.Request) {
+	resp := &GetLetterTemplatesResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view letter templates.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Order("name").Find(&resp.Templates).Error; err != nil {
+		log.Printf("[ERROR] could not get letter templates: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get letter templates", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to create a decision letter template
+// POST /settings/templates/create
+func PostCreateLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	resp := &CreateLetterTemplateResponse{}
+	resp.StandardResponse = StandardResponse{Message: "Letter template created successfully", Error: false}
+	reqBody := &LetterTemplateBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to create letter templates.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.ID, err = ControllerSaveLetterTemplate(0, reqBody); err != nil {
+		resp.StandardResponse = *letterTemplateErrorResponse(w, err, "could not create letter template")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to edit a decision letter template
+// POST /settings/templates/{id}/edit
+func PostEditLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Letter template edited successfully", Error: false}
+	reqBody := &LetterTemplateBody{}
+
+	params := mux.Vars(r)
+	templateID64, err := strconv.ParseUint(params["id"], 10, 32)
+	templateID := uint(templateID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Template ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to edit letter templates.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if _, err := ControllerSaveLetterTemplate(templateID, reqBody); err != nil {
+		resp = letterTemplateErrorResponse(w, err, "could not edit letter template")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to delete a decision letter template. Letters
+// written from it are kept with their decisions
+// POST /settings/templates/{id}/delete
+func PostDeleteLetterTemplate(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Letter template deleted successfully", Error: false}
+
+	params := mux.Vars(r)
+	templateID64, err := strconv.ParseUint(params["id"], 10, 32)
+	templateID := uint(templateID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Template ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to delete letter templates.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if res := gormDb.Unscoped().Delete(&LetterTemplate{}, templateID); res.Error != nil {
+		log.Printf("[ERROR] could not delete letter template: %v\n", res.Error)
+		resp = &StandardResponse{Message: "Internal Server Error - could not delete letter template", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if res.RowsAffected == 0 {
+		resp = &StandardResponse{Message: (&NoLetterTemplateError{ID: templateID}).Error(), Error: true}
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to render a letter template for a submission,
+// as a starting point for the letter of their decision
+// GET /submission/{id}/letter?template={templateID}&status={accepted|rejected}[&deadline={RFC3339 time}]
+func GetDecisionLetter(w http.ResponseWriter, r *http.Request) {
+	resp := &GetDecisionLetterResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	query := r.URL.Query()
+	templateID64, templateErr := strconv.ParseUint(query.Get("template"), 10, 32)
+	status := query.Get("status")
+	letter := &DecisionLetterBody{TemplateID: uint(templateID64)}
+	var deadlineErr error
+	if deadline := query.Get("deadline"); deadline != "" {
+		var deadlineTime time.Time
+		deadlineTime, deadlineErr = time.Parse(time.RFC3339, deadline)
+		letter.Deadline = &deadlineTime
+	}
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if templateErr != nil {
+		resp.StandardResponse = StandardResponse{Message: (&BadQueryParameterError{ParamName: "template", Value: query.Get("template")}).Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if status != STATUS_ACCEPTED && status != STATUS_REJECTED {
+		resp.StandardResponse = StandardResponse{Message: (&BadQueryParameterError{ParamName: "status", Value: status}).Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if deadlineErr != nil {
+		resp.StandardResponse = StandardResponse{Message: (&BadQueryParameterError{ParamName: "deadline", Value: query.Get("deadline")}).Error(), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to write decision letters.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if submission, err := getSubmission(submissionID); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not get submission: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not render decision letter", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+	} else if resp.Letter, err = getDecisionLetter(gormDb, submission, status, letter); err != nil {
+		resp.StandardResponse = *letterTemplateErrorResponse(w, err, "could not render decision letter")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which creates a decision letter template, or edits an existing one.
+//
+// Params:
+// 	templateID (uint) : the template to edit, 0 to create a template
+// 	r (*LetterTemplateBody) : the template's name and body
+// Returns:
+// 	(uint) : the ID of the template
+// 	(error) : an *UnknownPlaceholderError if the body uses an unknown placeholder, another error if one occurs
+func ControllerSaveLetterTemplate(templateID uint, r *LetterTemplateBody) (uint, error) {
+	if placeholder := findUnknownPlaceholder(r.Body); placeholder != "" {
+		return 0, &UnknownPlaceholderError{Placeholder: placeholder}
+	}
+	template := &LetterTemplate{}
+	err := gormDb.Transaction(func(tx *gorm.DB) error {
+		if templateID != 0 {
+			if res := tx.Limit(1).Find(template, templateID); res.Error != nil {
+				return res.Error
+			} else if res.RowsAffected == 0 {
+				return &NoLetterTemplateError{ID: templateID}
+			}
+		}
+		// template names are unique
+		other := &LetterTemplate{}
+		if res := tx.Where("name = ? AND id <> ?", r.Name, templateID).Limit(1).Find(other); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected > 0 {
+			return &DuplicateLetterTemplateError{Name: r.Name}
+		}
+		template.Name = r.Name
+		template.Body = r.Body
+		return tx.Save(template).Error
+	})
+	return template.ID, err
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the response for errors saving or rendering a letter template, setting
+// the matching status code
+func letterTemplateErrorResponse(w http.ResponseWriter, err error, action string) *StandardResponse {
+	switch err.(type) {
+	case *NoLetterTemplateError:
+		w.WriteHeader(http.StatusNotFound)
+	case *UnknownPlaceholderError, *DuplicateLetterTemplateError:
+		w.WriteHeader(http.StatusBadRequest)
+	default:
+		log.Printf("[ERROR] %s: %v\n", action, err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return &StandardResponse{Message: "Internal Server Error - " + action, Error: true}
+	}
+	return &StandardResponse{Message: err.Error(), Error: true}
+}
+
+// Gets the letter of a decision on a submission: the editor's letter, or the
+// given template's body if no letter is written, with its placeholders filled in.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submission (*Submission) : the submission, with its reviewers and reviews set
+// 	status (string) : the decision, accepted or rejected
+// 	letter (*DecisionLetterBody) : the editor's letter, template and deadline, nil for no letter
+// Returns:
+// 	(string) : the rendered letter, empty if there is none
+// 	(error) : a *NoLetterTemplateError if the template does not exist, another error if one occurs
+func getDecisionLetter(tx *gorm.DB, submission *Submission, status string, letter *DecisionLetterBody) (string, error) {
+	if letter == nil {
+		return "", nil
+	}
+	text := letter.Letter
+	if text == "" && letter.TemplateID != 0 {
+		template := &LetterTemplate{}
+		if res := tx.Limit(1).Find(template, letter.TemplateID); res.Error != nil {
+			return "", res.Error
+		} else if res.RowsAffected == 0 {
+			return "", &NoLetterTemplateError{ID: letter.TemplateID}
+		}
+		text = template.Body
+	}
+	deadline := ""
+	if letter.Deadline != nil {
+		deadline = letter.Deadline.Format(LETTER_DEADLINE_FORMAT)
+	}
+	return renderLetter(text, map[string]string{
+		PLACEHOLDER_SUBMISSION_NAME:    submission.Name,
+		PLACEHOLDER_DECISION:           status,
+		PLACEHOLDER_REVIEWER_SUMMARIES: summariseReviews(submission),
+		PLACEHOLDER_DEADLINE:           deadline,
+	}), nil
+}
+
+// fills in the placeholders of a letter with the given values, leaving
+// placeholders without a value as they are
+func renderLetter(text string, values map[string]string) string {
+	return placeholderRegex.ReplaceAllStringFunc(text, func(placeholder string) string {
+		if value, ok := values[placeholderRegex.FindStringSubmatch(placeholder)[1]]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// gets the first placeholder of a letter which is not supported, empty if there is none
+func findUnknownPlaceholder(text string) string {
+	known := map[string]bool{
+		PLACEHOLDER_SUBMISSION_NAME:    true,
+		PLACEHOLDER_DECISION:           true,
+		PLACEHOLDER_REVIEWER_SUMMARIES: true,
+		PLACEHOLDER_DEADLINE:           true,
+	}
+	for _, match := range placeholderRegex.FindAllStringSubmatch(text, -1) {
+		if !known[match[1]] {
+			return match[1]
+		}
+	}
+	return ""
+}
+
+// summarises the recommendations of the reviews of a submission's current
+// version by its assigned reviewers, one line per review. Reviewers are
+// numbered rather than named, as letters are sent to the authors
+func summariseReviews(submission *Submission) string {
+	lines := []string{}
+	for _, review := range currentRoundReviews(submission) {
+		if !isUserInList(review.ReviewerID, submission.Reviewers) {
+			continue
+		}
+		recommendation := "recommends rejection"
+		if review.Approved {
+			recommendation = "recommends acceptance"
+		}
+		lines = append(lines, fmt.Sprintf("Reviewer %d: %s", len(lines)+1, recommendation))
+	}
+	return strings.Join(lines, "\n")
+}
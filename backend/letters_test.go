@@ -0,0 +1,57 @@
+// =====================================
+// letters_test.go
+// Created: October 19, 2026
+//
+// test file for letters.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that placeholders are filled in, and unknown placeholders detected
+func TestRenderLetter(t *testing.T) {
+	values := map[string]string{PLACEHOLDER_SUBMISSION_NAME: "Sorting", PLACEHOLDER_DECISION: "accepted"}
+	testCases := []struct {
+		name     string
+		text     string
+		rendered string
+		unknown  string
+	}{
+		{"no placeholders", "Dear authors,", "Dear authors,", ""},
+		{"placeholders", "{{submission_name}} was {{decision}}. {{submission_name}}!", "Sorting was accepted. Sorting!", ""},
+		{"placeholder without value", "Due {{deadline}}", "Due {{deadline}}", ""},
+		{"unknown placeholder", "Dear {{author}},", "Dear {{author}},", "author"},
+		{"not a placeholder", "{{ decision }} {decision}", "{{ decision }} {decision}", ""},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.rendered, renderLetter(testCase.text, values), "wrong rendering")
+			assert.Equal(t, testCase.unknown, findUnknownPlaceholder(testCase.text), "wrong unknown placeholder")
+		})
+	}
+}
+
+// tests that only the current reviews of assigned reviewers are summarised, without naming them
+func TestSummariseReviews(t *testing.T) {
+	submission := &Submission{
+		Version:   2,
+		Reviewers: []GlobalUser{{ID: "reviewer1"}, {ID: "reviewer2"}},
+		MetaData: &SubmissionData{Reviews: []*Review{
+			{ReviewerID: "reviewer1", Approved: true, Round: 1},
+			{ReviewerID: "reviewer1", Approved: false, Round: 2},
+			{ReviewerID: "removed", Approved: true, Round: 2},
+			{ReviewerID: "reviewer2", Approved: true, Round: 2},
+		}},
+	}
+	assert.Equal(t, "Reviewer 1: recommends rejection\nReviewer 2: recommends acceptance",
+		summariseReviews(submission), "wrong summary")
+}
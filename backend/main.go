@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net/http"
 	"os"
@@ -44,14 +45,35 @@ var prodLogger logger.Interface = logger.New(log.New(os.Stdout, "\r\n", log.Lstd
 })
 
 func main() {
+	advisoryPath := flag.String("import-advisories", "", "import an OSV advisory dump (zip, directory or JSON file) then exit")
+	chiefEditorID := flag.String("chief-editor", "", "appoint an editor (by user ID) as chief editor then exit")
+	flag.Parse()
 
 	// Initialise database with production credentials.
 	var err error
 	if gormDb, err = gormInit(dbname, prodLogger); err != nil {
 		return
 	}
+
+	// Admin command: load security advisories offline instead of serving.
+	if *advisoryPath != "" {
+		if _, err := importAdvisories(gormDb, *advisoryPath); err != nil {
+			log.Fatalf("Advisory import failed: %v\n", err)
+		}
+		return
+	}
+	// Admin command: appoint a chief editor, i.e. the journal's first.
+	if *chiefEditorID != "" {
+		if err := appointChiefEditor(gormDb, *chiefEditorID); err != nil {
+			log.Fatalf("Chief editor appointment failed: %v\n", err)
+		}
+		return
+	}
 	setup(gormDb, os.Getenv("LOG_PATH"))
 
+	// Send review reminders and expire invitations in the background.
+	go runReviewScheduler(gormDb, REVIEW_SCHEDULER_INTERVAL)
+
 	done := make(chan os.Signal)
 	signal.Notify(done, os.Interrupt, syscall.SIGINT, syscall.SIGTERM)
 
@@ -97,6 +119,10 @@ func setupCORSsrv() *http.Server {
 	getUserSubroutes(router)        // Users subroutes
 	getSubmissionsSubRoutes(router) // Submissions and files routes
 	getFilesSubRoutes(router)
+	getSettingsSubRoutes(router)      // Journal settings routes
+	getRubricsSubRoutes(router)       // Review rubric routes
+	getAnalyticsSubRoutes(router)     // Editor analytics routes
+	getNotificationsSubRoutes(router) // User notification routes
 
 	// Setup HTTP server and shutdown signal notification
 	return &http.Server{
@@ -135,6 +161,12 @@ func setup(db *gorm.DB, logpath string) error {
 		}
 	} */
 
+	// Load the secret scanning rule set if one is configured.
+	if err = loadSecretRules(os.Getenv(SECRET_RULES_ENV)); err != nil {
+		log.Fatalf("FATAL - Secret scanning rules could not be loaded: %v\n", err)
+		goto RETURN
+	}
+
 	// Set foreign servers.
 	if err = setForeignServers(db); err != nil {
 		log.Fatalf("FATAL - Foreign server set up error: %v\n", err)
@@ -0,0 +1,192 @@
+// =========================================================================
+// mentions.go
+// Created: October 19, 2026
+//
+// This file takes care of @mentions in comments: users mentioned by ID
+// (@userId) or by name (@First Last) who can see the submission, and whose
+// identity is not hidden from the comment's author under blind review, are
+// recorded against the comment and notified. Other mentions stay plain text
+// =========================================================================
+
+package main
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// an @ not preceded by a word character (so emails are not mentions), then
+// one or two words: a user ID, or a first and last name
+var mentionPattern = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_])(@([\p{L}\p{N}_'-]+)(?:[ \t]+([\p{L}\p{N}_'-]+))?)`)
+
+// a possible mention found in a comment's body
+type mentionToken struct {
+	Text      string // the mention as written, with both words
+	ID        string // the first word, as a user ID
+	FirstName string
+	LastName  string // empty if the mention has a single word
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Records the users mentioned in a comment, replacing its previous mentions,
+// and notifies those newly mentioned (apart from its author).
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to record the mentions in
+// 	comment (*Comment) : the comment, with its ID, author and body set
+// 	submissionID (uint) : the submission the comment's file belongs to
+// Returns:
+// 	(error) : an error if one occurs
+func recordMentions(tx *gorm.DB, comment *Comment, submissionID uint) error {
+	previous := []CommentMention{}
+	if err := tx.Where("comment_id = ?", comment.ID).Find(&previous).Error; err != nil {
+		return err
+	} else if len(previous) > 0 {
+		if err := tx.Where("comment_id = ?", comment.ID).Delete(&CommentMention{}).Error; err != nil {
+			return err
+		}
+	}
+	tokens := parseMentions(commentText(comment.Base64Value))
+	if len(tokens) == 0 {
+		return nil
+	}
+
+	// gets the users the mentions could be of, keeping those who can see the submission
+	ids, names := []string{}, []string{}
+	for _, token := range tokens {
+		ids = append(ids, token.ID)
+		if token.LastName != "" {
+			names = append(names, token.FirstName+" "+token.LastName)
+		}
+	}
+	query := tx.Where("id IN ?", ids)
+	if len(names) > 0 {
+		query = query.Or("CONCAT(first_name, ' ', last_name) IN ?", names)
+	}
+	candidates := []GlobalUser{}
+	if err := query.Find(&candidates).Error; err != nil {
+		return err
+	}
+	submission := &Submission{}
+	if err := tx.Preload("Authors").Preload("Reviewers").Select("id, status, review_mode").
+		Find(submission, submissionID).Error; err != nil {
+		return err
+	}
+
+	// mentions of users hidden from the comment's author would confirm who they are
+	author := &GlobalUser{ID: comment.AuthorID}
+	if err := tx.Select("id, user_type").Limit(1).Find(author, "id = ?", comment.AuthorID).Error; err != nil {
+		return err
+	}
+	mode, err := getReviewMode(tx, submission)
+	if err != nil {
+		return err
+	}
+	hidden, err := getSubmissionAliases(tx, submission, mode, &RequestContext{ID: author.ID, UserType: author.UserType})
+	if err != nil {
+		return err
+	}
+	visible := []GlobalUser{}
+	for _, user := range candidates {
+		if _, ok := hidden[user.ID]; !ok && canViewSubmission(submission, &RequestContext{ID: user.ID, UserType: user.UserType}) {
+			visible = append(visible, user)
+		}
+	}
+
+	mentions := resolveMentions(tokens, visible)
+	if len(mentions) == 0 {
+		return nil
+	}
+	notifications := []Notification{}
+	for i := range mentions {
+		mentions[i].CommentID = comment.ID
+		if mentions[i].UserID != comment.AuthorID && !isMentioned(mentions[i].UserID, previous) {
+			notifications = append(notifications, Notification{UserID: mentions[i].UserID, Type: NOTIFICATION_MENTION,
+				SubmissionID: submissionID, FileID: comment.FileID, CommentID: comment.ID})
+		}
+	}
+	if err := tx.Create(&mentions).Error; err != nil {
+		return err
+	}
+	return notify(tx, notifications)
+}
+
+// gets a comment's text from its body, decoding it if it is base64 encoded
+func commentText(base64Value string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(base64Value); err == nil {
+		return string(decoded)
+	}
+	return base64Value
+}
+
+// replaces text in a comment's body, keeping it base64 encoded if it is
+func replaceCommentText(base64Value string, old string, new string) string {
+	if decoded, err := base64.StdEncoding.DecodeString(base64Value); err == nil {
+		return base64.StdEncoding.EncodeToString([]byte(strings.ReplaceAll(string(decoded), old, new)))
+	}
+	return strings.ReplaceAll(base64Value, old, new)
+}
+
+// finds the possible mentions in a comment's text, in order
+func parseMentions(text string) []mentionToken {
+	tokens := []mentionToken{}
+	for _, match := range mentionPattern.FindAllStringSubmatch(text, -1) {
+		tokens = append(tokens, mentionToken{Text: match[1], ID: match[2], FirstName: match[2], LastName: match[3]})
+	}
+	return tokens
+}
+
+// Resolves possible mentions to users. A mention is of the user with its
+// first word as ID, else of the only user with its words as first and last
+// name. Mentions of no user, or of several users sharing a name, are left out,
+// as are repeated mentions of a user.
+//
+// Params:
+// 	tokens ([]mentionToken) : the possible mentions (see parseMentions)
+// 	users ([]GlobalUser) : the users who can be mentioned
+// Returns:
+// 	([]CommentMention) : the mentions, without their comment set
+func resolveMentions(tokens []mentionToken, users []GlobalUser) []CommentMention {
+	mentions := []CommentMention{}
+	mentioned := make(map[string]bool)
+	for _, token := range tokens {
+		userID, text := "", token.Text
+		for _, user := range users {
+			if user.ID == token.ID {
+				userID, text = user.ID, "@"+token.ID // the second word is not part of an ID mention
+			}
+		}
+		if userID == "" && token.LastName != "" {
+			matches := []string{}
+			for _, user := range users {
+				if strings.EqualFold(user.FirstName, token.FirstName) && strings.EqualFold(user.LastName, token.LastName) {
+					matches = append(matches, user.ID)
+				}
+			}
+			if len(matches) == 1 {
+				userID = matches[0]
+			}
+		}
+		if userID != "" && !mentioned[userID] {
+			mentioned[userID] = true
+			mentions = append(mentions, CommentMention{UserID: userID, Text: text})
+		}
+	}
+	return mentions
+}
+
+// checks whether a user is among a comment's mentions
+func isMentioned(userID string, mentions []CommentMention) bool {
+	for _, mention := range mentions {
+		if mention.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,50 @@
+// =====================================
+// mentions_test.go
+// Created: October 19, 2026
+//
+// test file for mentions.go
+// =====================================
+
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that mentions are found at word boundaries, with up to two words
+func TestParseMentions(t *testing.T) {
+	tokens := parseMentions("@11abc-def please see, cc @Jane Doe. Not an email: jane@doe.com")
+	switch {
+	case !assert.Len(t, tokens, 2, "wrong number of mentions"),
+		!assert.Equal(t, mentionToken{Text: "@11abc-def please", ID: "11abc-def", FirstName: "11abc-def", LastName: "please"}, tokens[0], "wrong ID mention"),
+		!assert.Equal(t, mentionToken{Text: "@Jane Doe", ID: "Jane", FirstName: "Jane", LastName: "Doe"}, tokens[1], "wrong name mention"):
+		return
+	}
+	assert.Equal(t, "@Jane Doe", commentText(base64.StdEncoding.EncodeToString([]byte("@Jane Doe"))), "comment body not decoded")
+}
+
+// tests that mentions are resolved by ID then by name, unknown and ambiguous mentions staying plain text
+func TestResolveMentions(t *testing.T) {
+	users := []GlobalUser{
+		{ID: "author", FirstName: "Jane", LastName: "Doe"},
+		{ID: "reviewer1", FirstName: "John", LastName: "Smith"},
+		{ID: "reviewer2", FirstName: "John", LastName: "Smith"},
+	}
+	tokens := parseMentions("@reviewer1 thanks, @jane doe and @John Smith, @unknown user, again @author")
+	mentions := resolveMentions(tokens, users)
+	switch {
+	case !assert.Len(t, mentions, 2, "wrong number of mentions"),
+		!assert.Equal(t, CommentMention{UserID: "reviewer1", Text: "@reviewer1"}, mentions[0], "wrong ID mention"),
+		!assert.Equal(t, CommentMention{UserID: "author", Text: "@jane doe"}, mentions[1], "wrong name mention"):
+		return
+	}
+	assert.True(t, isMentioned("author", mentions), "mentioned user not found")
+	assert.False(t, isMentioned("reviewer2", mentions), "ambiguous mention resolved")
+}
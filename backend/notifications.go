@@ -0,0 +1,144 @@
+// =========================================================================
+// notifications.go
+// Created: October 19, 2026
+//
+// This file takes care of users' notifications (i.e. of being mentioned in a
+// comment). The journal has no mail service, so notifications are logged and
+// kept for users to fetch and mark as read
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	SUBROUTE_NOTIFICATIONS = "/notifications"
+	ENDPOINT_READ          = "/read"
+
+	// types of notifications
+	NOTIFICATION_MENTION = "mention" // the user was mentioned in a comment
+)
+
+// Describe mux routing for notifications endpoints.
+func getNotificationsSubRoutes(r *mux.Router) {
+	notifications := r.PathPrefix(SUBROUTE_NOTIFICATIONS).Subrouter()
+	notifications.Use(jwtMiddleware)
+
+	// Notifications routes:
+	// + GET /notifications - Get the logged in user's notifications, newest first.
+	// + POST /notifications/read - Mark the logged in user's notifications as read.
+	notifications.HandleFunc("", GetNotifications).Methods(http.MethodGet)
+	notifications.HandleFunc(ENDPOINT_READ, PostReadNotifications).Methods(http.MethodPost, http.MethodOptions)
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function to get the logged in user's notifications, newest first.
+// Only unread notifications are returned if unread=true is given
+// GET /notifications?unread=true
+func GetNotifications(w http.ResponseWriter, r *http.Request) {
+	resp := &GetNotificationsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if notifications, err := getNotifications(ctx.ID, r.URL.Query().Get("unread") == "true"); err != nil {
+		log.Printf("[ERROR] could not get notifications: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get notifications", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else {
+		resp.Notifications = notifications
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function to mark the logged in user's notifications as read, all of
+// them if none are given
+// POST /notifications/read
+func PostReadNotifications(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Notifications marked as read successfully", Error: false}
+	reqBody := &ReadNotificationsBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := readNotifications(ctx.ID, reqBody.IDs, time.Now()); err != nil {
+		log.Printf("[ERROR] could not mark notifications as read: %v\n", err)
+		resp = &StandardResponse{Message: "Internal Server Error - could not mark notifications as read", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Sends notifications to users, logging them and keeping them for the users to fetch.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to store the notifications on (may be a transaction)
+// 	notifications ([]Notification) : the notifications, with their user set
+// Returns:
+// 	(error) : an error if one occurs
+func notify(tx *gorm.DB, notifications []Notification) error {
+	if len(notifications) == 0 {
+		return nil
+	} else if err := tx.Create(&notifications).Error; err != nil {
+		return err
+	}
+	for _, notification := range notifications {
+		log.Printf("[INFO] notifying user %s of %s on submission %d\n",
+			notification.UserID, notification.Type, notification.SubmissionID)
+	}
+	return nil
+}
+
+// gets a user's notifications, newest first
+func getNotifications(userID string, unreadOnly bool) ([]Notification, error) {
+	notifications := []Notification{}
+	query := gormDb.Where("user_id = ?", userID)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+	if err := query.Order("id desc").Find(&notifications).Error; err != nil {
+		return nil, err
+	}
+	return notifications, nil
+}
+
+// marks a user's unread notifications as read, all of them if no IDs are given
+func readNotifications(userID string, notificationIDs []uint, now time.Time) error {
+	query := gormDb.Model(&Notification{}).Where("user_id = ? AND read_at IS NULL", userID)
+	if len(notificationIDs) > 0 {
+		query = query.Where("id IN ?", notificationIDs)
+	}
+	return query.Update("read_at", now).Error
+}
@@ -0,0 +1,371 @@
+// =========================================================================
+// policies.go
+// Created: October 19, 2026
+//
+// This file takes care of acceptance policies, the rules deciding whether an
+// editor can accept a submission given its reviews. The journal sets a
+// policy which tags can override, and every decision records the policy
+// applied to it
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_POLICIES   = "/policies"
+	ENDPOINT_SET_POLICY = "/set"
+	ENDPOINT_DECISIONS  = "/decisions"
+
+	// acceptance policies
+	ACCEPTANCE_UNANIMOUS       = "unanimous"       // every reviewer reviewed and approves
+	ACCEPTANCE_MAJORITY        = "majority"        // more than half of the reviewers approve
+	ACCEPTANCE_MIN_APPROVALS   = "min_approvals"   // at least a minimum number of reviewers approve
+	ACCEPTANCE_EDITOR_OVERRIDE = "editor_override" // the editor decides alone, with a justification
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to get the acceptance policies set for tags
+// GET /settings/policies
+func GetAcceptancePolicies(w http.ResponseWriter, r *http.Request) {
+	resp := &GetAcceptancePoliciesResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view acceptance policies.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Order("tag").Find(&resp.Policies).Error; err != nil {
+		log.Printf("[ERROR] could not get acceptance policies: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get acceptance policies", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to set the acceptance policy of submissions with a tag
+// POST /settings/policies/set
+func PostSetAcceptancePolicy(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Acceptance policy set successfully", Error: false}
+	reqBody := &SetAcceptancePolicyBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to set acceptance policies.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerSetAcceptancePolicy(reqBody); err != nil {
+		log.Printf("[ERROR] could not set acceptance policy: %v\n", err)
+		resp = &StandardResponse{Message: "Internal Server Error - could not set acceptance policy", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to make submissions with a tag use the journal's
+// acceptance policy again
+// POST /settings/policies/delete
+func PostDeleteAcceptancePolicy(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Acceptance policy deleted successfully", Error: false}
+	reqBody := &DeleteAcceptancePolicyBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to delete acceptance policies.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if res := gormDb.Unscoped().Where("tag = ?", reqBody.Tag).Delete(&AcceptancePolicy{}); res.Error != nil {
+		log.Printf("[ERROR] could not delete acceptance policy: %v\n", res.Error)
+		resp = &StandardResponse{Message: "Internal Server Error - could not delete acceptance policy", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if res.RowsAffected == 0 {
+		resp = &StandardResponse{Message: "No acceptance policy is set for tag " + reqBody.Tag, Error: true}
+		w.WriteHeader(http.StatusNotFound)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function to get the accept and reject decisions made on a submission,
+// for editors and the submission's authors and reviewers
+// GET /submission/{id}/decisions
+func GetSubmissionDecisions(w http.ResponseWriter, r *http.Request) {
+	resp := &GetDecisionsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	submission := &Submission{}
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if res := gormDb.Preload("Authors").Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+		log.Printf("[ERROR] could not get submission: %v\n", res.Error)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get decisions", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if res.RowsAffected == 0 {
+		resp.StandardResponse = StandardResponse{Message: (&NoSubmissionError{ID: submissionID}).Error(), Error: true}
+		w.WriteHeader(http.StatusNotFound)
+
+	} else if ctx.UserType != USERTYPE_EDITOR && !isUserInList(ctx.ID, submission.Authors) && !isUserInList(ctx.ID, submission.Reviewers) {
+		resp.StandardResponse = StandardResponse{Message: "Only editors, authors and reviewers can view a submission's decisions.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Where("submission_id = ?", submissionID).Order("created_at").Find(&resp.Decisions).Error; err != nil {
+		log.Printf("[ERROR] could not get decisions: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get decisions", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which sets the acceptance policy of submissions with a tag,
+// replacing the policy already set for it.
+//
+// Params:
+// 	r (*SetAcceptancePolicyBody) : the tag and its policy
+// Returns:
+// 	(error) : an error if one occurs
+func ControllerSetAcceptancePolicy(r *SetAcceptancePolicyBody) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		policy := &AcceptancePolicy{}
+		if err := tx.Where("tag = ?", r.Tag).Limit(1).Find(policy).Error; err != nil {
+			return err
+		}
+		policy.Tag = r.Tag
+		policy.Policy = r.Policy
+		policy.MinApprovals = r.MinApprovals
+		return tx.Save(policy).Error
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Gets the acceptance policy applying to a submission: the strictest of the
+// policies set for its tags (see strictestAcceptancePolicy), else the journal's.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submission (*Submission) : the submission, with its categories and reviewers set
+// Returns:
+// 	(*AcceptancePolicy) : the policy, with an empty tag if it is the journal's
+// 	(error) : an error if one occurs
+func getAcceptancePolicy(tx *gorm.DB, submission *Submission) (*AcceptancePolicy, error) {
+	if tags := getTagArray(submission.Categories); len(tags) > 0 {
+		policies := []AcceptancePolicy{}
+		if err := tx.Where("tag IN ?", tags).Order("tag").Find(&policies).Error; err != nil {
+			return nil, err
+		} else if len(policies) > 0 {
+			return strictestAcceptancePolicy(policies, len(submission.Reviewers)), nil
+		}
+	}
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return nil, err
+	}
+	return &AcceptancePolicy{Policy: settings.AcceptancePolicy, MinApprovals: settings.MinApprovals}, nil
+}
+
+// Picks the strictest of several acceptance policies for a submission: the
+// one requiring the most approvals from its reviewers, then unanimity over a
+// minimum number of approvals over a majority, then the first by tag. The
+// policy applied does not depend on the order the policies were set in.
+//
+// Params:
+// 	policies ([]AcceptancePolicy) : the policies, ordered by tag
+// 	reviewerCount (int) : the number of reviewers of the submission
+// Returns:
+// 	(*AcceptancePolicy) : the strictest policy
+func strictestAcceptancePolicy(policies []AcceptancePolicy, reviewerCount int) *AcceptancePolicy {
+	rank := map[string]int{ACCEPTANCE_EDITOR_OVERRIDE: 0, ACCEPTANCE_MAJORITY: 1, ACCEPTANCE_MIN_APPROVALS: 2, ACCEPTANCE_UNANIMOUS: 3}
+	strictest := &policies[0]
+	for i := range policies[1:] {
+		policy := &policies[i+1]
+		required, strictestRequired := requiredApprovals(policy, reviewerCount), requiredApprovals(strictest, reviewerCount)
+		if required > strictestRequired || (required == strictestRequired && rank[policy.Policy] > rank[strictest.Policy]) {
+			strictest = policy
+		}
+	}
+	return strictest
+}
+
+// gets the number of approvals a policy requires from a submission's reviewers
+func requiredApprovals(policy *AcceptancePolicy, reviewerCount int) int {
+	switch policy.Policy {
+	case ACCEPTANCE_EDITOR_OVERRIDE:
+		return 0
+	case ACCEPTANCE_MAJORITY:
+		return reviewerCount/2 + 1
+	case ACCEPTANCE_MIN_APPROVALS:
+		if policy.MinApprovals < 1 {
+			return 1
+		}
+		return policy.MinApprovals
+	}
+	return reviewerCount
+}
+
+// counts the reviews of a submission's current version by its assigned
+// reviewers, and how many of them approve it
+func countApprovals(submission *Submission) (int, int) {
+	reviews, approvals := 0, 0
+	for _, review := range currentRoundReviews(submission) {
+		if isUserInList(review.ReviewerID, submission.Reviewers) {
+			reviews++
+			if review.Approved {
+				approvals++
+			}
+		}
+	}
+	return reviews, approvals
+}
+
+// Checks that a submission's reviews meet an acceptance policy. A rule which
+// is not met yet but could still be by the missing reviews gives a
+// *MissingReviewsError, else a *MissingApprovalError.
+//
+// Params:
+// 	policy (*AcceptancePolicy) : the policy applying to the submission
+// 	submission (*Submission) : the submission, with its reviewers and reviews set
+// 	justification (string) : the editor's justification for accepting the submission
+// Returns:
+// 	(error) : an error if the submission cannot be accepted under the policy
+func checkAcceptancePolicy(policy *AcceptancePolicy, submission *Submission, justification string) error {
+	reviews, approvals := countApprovals(submission)
+	pending := len(submission.Reviewers) - reviews
+	required := 0
+	switch policy.Policy {
+	case ACCEPTANCE_EDITOR_OVERRIDE:
+		if strings.TrimSpace(justification) == "" {
+			return &MissingJustificationError{SubmissionID: submission.ID, Policy: policy.Policy}
+		}
+		return nil
+	case ACCEPTANCE_MAJORITY, ACCEPTANCE_MIN_APPROVALS:
+		required = requiredApprovals(policy, len(submission.Reviewers))
+	default:
+		return checkReviewsApprove(submission)
+	}
+
+	if len(submission.Reviewers) == 0 {
+		return &MissingReviewsError{SubmissionID: submission.ID}
+	} else if approvals >= required {
+		return nil
+	} else if approvals+pending >= required {
+		return &MissingReviewsError{SubmissionID: submission.ID}
+	}
+	return &MissingApprovalError{SubmissionID: submission.ID}
+}
+
+// Accepts or rejects a submission, checking acceptances against the policy
+// applying to the submission, and records the decision.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to update the submission in
+// 	submission (*Submission) : the submission, with its categories, reviewers and reviews set
+// 	status (string) : accepted or rejected
+// 	editorID (string) : the editor making the decision
+// 	justification (string) : the editor's justification for the decision
+// 	letter (*DecisionLetterBody) : the editor's letter to the authors, nil for no letter
+// Returns:
+// 	(error) : an error if the decision is not allowed or fails
+func decideSubmission(tx *gorm.DB, submission *Submission, status string, editorID string, justification string, letter *DecisionLetterBody) error {
+	if !canTransition(submission.Status, status) {
+		return &InvalidStatusTransitionError{SubmissionID: submission.ID, From: submission.Status, To: status}
+	} else if err := checkHandlingEditor(tx, submission, editorID); err != nil {
+		return err
+	}
+	policy, err := getAcceptancePolicy(tx, submission)
+	if err != nil {
+		return err
+	} else if status == STATUS_ACCEPTED {
+		if err := checkAcceptancePolicy(policy, submission, justification); err != nil {
+			return err
+		}
+	}
+	// the letter is rendered before the status changes so that a missing template is reported first
+	letterText, err := getDecisionLetter(tx, submission, status, letter)
+	if err != nil {
+		return err
+	} else if err := setSubmissionStatus(tx, submission, status, editorID); err != nil {
+		return err
+	}
+
+	reviews, approvals := countApprovals(submission)
+	decision := &SubmissionDecision{
+		SubmissionID:  submission.ID,
+		Version:       submission.Version,
+		EditorID:      editorID,
+		Status:        status,
+		Policy:        policy.Policy,
+		PolicyTag:     policy.Tag,
+		Reviewers:     len(submission.Reviewers),
+		Reviews:       reviews,
+		Approvals:     approvals,
+		Justification: justification,
+		Letter:        letterText,
+	}
+	if letter != nil {
+		decision.Deadline = letter.Deadline
+	}
+	if policy.Policy == ACCEPTANCE_MIN_APPROVALS {
+		decision.MinApprovals = policy.MinApprovals
+	}
+	return tx.Create(decision).Error
+}
@@ -0,0 +1,99 @@
+// =====================================
+// policies_test.go
+// Created: October 19, 2026
+//
+// test file for policies.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests each acceptance policy against the reviews of a submission
+func TestCheckAcceptancePolicy(t *testing.T) {
+	// builds a submission with three reviewers and the given reviews of its current version
+	withReviews := func(reviews ...*Review) *Submission {
+		return &Submission{
+			Version:   1,
+			Reviewers: []GlobalUser{{ID: "a"}, {ID: "b"}, {ID: "c"}},
+			MetaData:  &SubmissionData{Reviews: reviews},
+		}
+	}
+	unanimous := &AcceptancePolicy{Policy: ACCEPTANCE_UNANIMOUS}
+	majority := &AcceptancePolicy{Policy: ACCEPTANCE_MAJORITY}
+	minApprovals := &AcceptancePolicy{Policy: ACCEPTANCE_MIN_APPROVALS, MinApprovals: 1}
+	override := &AcceptancePolicy{Policy: ACCEPTANCE_EDITOR_OVERRIDE}
+
+	testCases := []struct {
+		name          string
+		policy        *AcceptancePolicy
+		submission    *Submission
+		justification string
+		err           error
+	}{
+		{"unanimous missing review", unanimous, withReviews(&Review{ReviewerID: "a", Approved: true}, &Review{ReviewerID: "b", Approved: true}),
+			"", &MissingReviewsError{}},
+		{"majority reached", majority, withReviews(&Review{ReviewerID: "a", Approved: true}, &Review{ReviewerID: "b", Approved: true}),
+			"", nil},
+		{"majority pending", majority, withReviews(&Review{ReviewerID: "a", Approved: true}, &Review{ReviewerID: "b", Approved: false}),
+			"", &MissingReviewsError{}},
+		{"majority failed", majority, withReviews(&Review{ReviewerID: "a", Approved: false}, &Review{ReviewerID: "b", Approved: false}),
+			"", &MissingApprovalError{}},
+		{"unassigned reviewer not counted", majority, withReviews(&Review{ReviewerID: "a", Approved: true},
+			&Review{ReviewerID: "d", Approved: true}, &Review{ReviewerID: "b", Approved: false}, &Review{ReviewerID: "c", Approved: false}),
+			"", &MissingApprovalError{}},
+		{"minimum approvals reached", minApprovals, withReviews(&Review{ReviewerID: "c", Approved: true}), "", nil},
+		{"no reviewers", minApprovals, &Submission{Version: 1}, "", &MissingReviewsError{}},
+		{"override justified", override, withReviews(), "fixes a critical bug", nil},
+		{"override unjustified", override, withReviews(&Review{ReviewerID: "a", Approved: true}), " ", &MissingJustificationError{}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := checkAcceptancePolicy(testCase.policy, testCase.submission, testCase.justification)
+			if testCase.err == nil {
+				assert.NoError(t, err, "submission not accepted")
+			} else {
+				assert.IsType(t, testCase.err, err, "wrong error")
+			}
+		})
+	}
+}
+
+// tests that the strictest policy applies to a submission with conflicting tags, whatever order they were set in
+func TestStrictestAcceptancePolicy(t *testing.T) {
+	testCases := []struct {
+		name      string
+		policies  []AcceptancePolicy
+		reviewers int
+		tag       string
+	}{
+		{"unanimous over majority", []AcceptancePolicy{
+			{Tag: "a", Policy: ACCEPTANCE_MAJORITY}, {Tag: "b", Policy: ACCEPTANCE_UNANIMOUS}}, 3, "b"},
+		{"majority over override", []AcceptancePolicy{
+			{Tag: "a", Policy: ACCEPTANCE_MAJORITY}, {Tag: "b", Policy: ACCEPTANCE_EDITOR_OVERRIDE}}, 3, "a"},
+		{"more approvals over majority", []AcceptancePolicy{
+			{Tag: "a", Policy: ACCEPTANCE_MAJORITY}, {Tag: "b", Policy: ACCEPTANCE_MIN_APPROVALS, MinApprovals: 4}}, 5, "b"},
+		{"majority over fewer approvals", []AcceptancePolicy{
+			{Tag: "a", Policy: ACCEPTANCE_MIN_APPROVALS, MinApprovals: 2}, {Tag: "b", Policy: ACCEPTANCE_MAJORITY}}, 5, "b"},
+		{"equal policies by tag", []AcceptancePolicy{
+			{Tag: "a", Policy: ACCEPTANCE_MAJORITY}, {Tag: "b", Policy: ACCEPTANCE_MAJORITY}}, 3, "a"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.tag, strictestAcceptancePolicy(testCase.policies, testCase.reviewers).Tag, "wrong policy applied")
+			// the policies set in the other order
+			reversed := []AcceptancePolicy{testCase.policies[1], testCase.policies[0]}
+			if reversed[0].Policy != reversed[1].Policy {
+				assert.Equal(t, testCase.tag, strictestAcceptancePolicy(reversed, testCase.reviewers).Tag, "policy depends on order")
+			}
+		})
+	}
+}
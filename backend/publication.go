@@ -0,0 +1,221 @@
+// =========================================================================
+// publication.go
+// Created: October 19, 2026
+//
+// This file takes care of open peer review: if the journal allows it and a
+// submission's authors opt in, the reviews of the accepted submission are
+// published with it. Reviewers choose whether they are named or anonymous
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_PUBLISH_REVIEWS = "/publishreviews"
+	ENDPOINT_REVIEW_IDENTITY = "/review/identity"
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for authors to choose whether the reviews of their
+// submission are published once it is accepted
+// POST /submission/{id}/publishreviews
+func PostPublishReviews(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Review publication updated successfully", Error: false}
+	reqBody := &PublishReviewsBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerPublishReviews(submissionID, reqBody.Publish, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp = &StandardResponse{Message: "Only the submission's authors can choose to publish its reviews.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] could not update review publication: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not update review publication", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for reviewers to choose whether they are named on their
+// published reviews of a submission
+// POST /submission/{id}/review/identity
+func PostReviewIdentity(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Review identity updated successfully", Error: false}
+	reqBody := &ReviewIdentityBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerReviewIdentity(submissionID, ctx.ID, reqBody.Named); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError, *NoReviewError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not update review identity: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not update review identity", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which sets whether a submission's reviews are published once it
+// is accepted, if the journal allows it.
+//
+// Params:
+// 	submissionID (uint) : the submission
+// 	publish (bool) : true to publish the reviews, false to keep them private
+// 	ctx (*RequestContext) : the logged in user, who must be an author
+// Returns:
+// 	(error) : an error if one occurs
+func ControllerPublishReviews(submissionID uint, publish bool, ctx *RequestContext) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Authors").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		} else if !isUserInList(ctx.ID, submission.Authors) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		} else if submission.PublishReviews == publish {
+			return nil
+		}
+		if err := tx.Model(&Submission{}).Where("id = ?", submissionID).Update("publish_reviews", publish).Error; err != nil {
+			return err
+		}
+		return recordEvent(tx, submissionID, EVENT_EDITED, ctx.ID, false,
+			map[string]string{"publishReviews": strconv.FormatBool(publish)})
+	})
+}
+
+// Controller which sets whether a reviewer is named on their published
+// reviews of a submission. Applies to all their reviews of it, and can be
+// changed after the submission is decided.
+//
+// Params:
+// 	submissionID (uint) : the submission
+// 	reviewerID (string) : the reviewer
+// 	named (bool) : true to be named, false to stay anonymous
+// Returns:
+// 	(error) : a *NoReviewError if the reviewer has no review of the submission, another error if one occurs
+func ControllerReviewIdentity(submissionID uint, reviewerID string, named bool) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that its metadata is not rewritten by concurrent changes
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, review := range submission.MetaData.Reviews {
+			if review.ReviewerID == reviewerID {
+				review.Named = named
+				found = true
+			}
+		}
+		if !found {
+			return &NoReviewError{UserID: reviewerID, SubmissionID: submissionID}
+		}
+		return addMetaData(submission)
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// checks whether a user took part in a submission's review (i.e. is an
+// editor, or one of its authors or reviewers)
+func isReviewParticipant(submission *Submission, ctx *RequestContext) bool {
+	return ctx != nil && (ctx.UserType == USERTYPE_EDITOR || isUserInList(ctx.ID, submission.Authors) ||
+		isUserInList(ctx.ID, submission.Reviewers))
+}
+
+// checks whether a submission's reviews are published: the journal allows it,
+// the authors opted in and the submission is accepted
+func reviewsPublished(submission *Submission, settings *JournalSettings) bool {
+	return settings.PublishReviews && submission.PublishReviews && submission.Status == STATUS_ACCEPTED
+}
+
+// Keeps only the published reviews of a submission, for users who did not
+// take part in its review: none if the reviews are not published, else the
+// reviews of the accepted version.
+//
+// Params:
+// 	submission (*Submission) : the submission, with its reviewers and metadata set
+// 	invited ([]string) : the reviewers ever invited to review the submission, in the order they were invited
+// 	published (bool) : true if the submission's reviews are published
+// Returns:
+// 	(map[string]string) : the aliases of the reviewers who stay anonymous, regardless of the review mode
+func publishReviews(submission *Submission, invited []string, published bool) map[string]string {
+	aliases := make(map[string]string)
+	addReviewerAliases(aliases, invited, submission.Reviewers)
+	submission.RubricScores = nil
+	if submission.MetaData == nil {
+		return aliases
+	} else if !published {
+		submission.MetaData.Reviews = []*Review{}
+		return aliases
+	}
+	reviews := currentRoundReviews(submission)
+	for _, review := range reviews {
+		if review.Named {
+			delete(aliases, review.ReviewerID)
+		}
+	}
+	submission.MetaData.Reviews = reviews
+	return aliases
+}
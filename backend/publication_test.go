@@ -0,0 +1,79 @@
+// =====================================
+// publication_test.go
+// Created: October 19, 2026
+//
+// test file for publication.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that reviews are only published if the journal and authors allow it, once accepted
+func TestReviewsPublished(t *testing.T) {
+	testCases := []struct {
+		name      string
+		journal   bool
+		authors   bool
+		status    string
+		published bool
+	}{
+		{"Published", true, true, STATUS_ACCEPTED, true},
+		{"Journal disallows", false, true, STATUS_ACCEPTED, false},
+		{"Authors did not opt in", true, false, STATUS_ACCEPTED, false},
+		{"Not accepted", true, true, STATUS_UNDER_REVIEW, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			submission := &Submission{PublishReviews: testCase.authors, Status: testCase.status}
+			settings := &JournalSettings{PublishReviews: testCase.journal}
+			assert.Equal(t, testCase.published, reviewsPublished(submission, settings), "wrong publication")
+		})
+	}
+}
+
+// tests that only current reviews are published, with anonymous reviewers aliased
+func TestPublishReviews(t *testing.T) {
+	newSubmission := func() *Submission {
+		return &Submission{
+			Version:   2,
+			Reviewers: []GlobalUser{{ID: "reviewer1"}, {ID: "reviewer2"}},
+			MetaData: &SubmissionData{Reviews: []*Review{
+				{ReviewerID: "reviewer1", Round: 1, Named: true},
+				{ReviewerID: "reviewer1", Round: 2, Named: true},
+				{ReviewerID: "reviewer2", Round: 2},
+			}},
+			RubricScores: []CriterionAggregate{{CriterionID: 1}},
+		}
+	}
+
+	t.Run("Not published", func(t *testing.T) {
+		submission := newSubmission()
+		aliases := publishReviews(submission, nil, false)
+		switch {
+		case !assert.Empty(t, submission.MetaData.Reviews, "unpublished reviews shown"),
+			!assert.Nil(t, submission.RubricScores, "unpublished scores shown"),
+			!assert.Len(t, aliases, 2, "reviewers not aliased"):
+			return
+		}
+	})
+
+	t.Run("Published", func(t *testing.T) {
+		submission := newSubmission()
+		aliases := publishReviews(submission, nil, true)
+		switch {
+		case !assert.Len(t, submission.MetaData.Reviews, 2, "previous round published"),
+			!assert.NotContains(t, aliases, "reviewer1", "named reviewer aliased"),
+			!assert.Equal(t, "Reviewer 2", aliases["reviewer2"], "anonymous reviewer not aliased"):
+			return
+		}
+	})
+}
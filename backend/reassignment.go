@@ -0,0 +1,221 @@
+// =========================================================================
+// reassignment.go
+// Created: October 19, 2026
+//
+// This file takes care of editors unassigning reviewers from a submission,
+// or replacing them by another reviewer. Reviews the unassigned reviewer left
+// on the current version are archived rather than dropped
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_UNASSIGN_REVIEWER = "/unassignreviewer"
+	ENDPOINT_REPLACE_REVIEWER  = "/replacereviewer"
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to unassign a reviewer from a submission
+// POST /submission/{id}/unassignreviewer
+func PostUnassignReviewer(w http.ResponseWriter, r *http.Request) {
+	resp := &AssignReviewersResponse{}
+	resp.StandardResponse = StandardResponse{Message: "Reviewer unassigned successfully", Error: false}
+	reqBody := &UnassignReviewerBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to unassign reviewers.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerUnassignReviewer(submissionID, reqBody.Reviewer, reqBody.Reason, ctx.ID, nil); err != nil {
+		reassignmentErrorResponse(w, err, resp, "could not unassign reviewer")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to replace a reviewer of a submission by another
+// reviewer, who is invited to review it
+// POST /submission/{id}/replacereviewer
+func PostReplaceReviewer(w http.ResponseWriter, r *http.Request) {
+	resp := &AssignReviewersResponse{}
+	resp.StandardResponse = StandardResponse{Message: "Reviewer replaced successfully", Error: false}
+	reqBody := &ReplaceReviewerBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to replace reviewers.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if reqBody.DueDate != nil && !reqBody.DueDate.After(time.Now()) {
+		resp.StandardResponse = StandardResponse{Message: "The review due date must be in the future.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerUnassignReviewer(submissionID, reqBody.Reviewer, reqBody.Reason, ctx.ID, reqBody); err != nil {
+		reassignmentErrorResponse(w, err, resp, "could not replace reviewer")
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which unassigns a reviewer from a submission, optionally
+// assigning a replacement. The reviewer's pending invitation is closed, their
+// review of the current version is archived, and the removal is recorded.
+//
+// Params:
+// 	submissionID (uint) : the submission the reviewer is unassigned from
+// 	reviewerID (string) : the reviewer to unassign
+// 	reason (string) : the editor's reason for unassigning the reviewer
+// 	editorID (string) : the editor unassigning the reviewer
+// 	replacement (*ReplaceReviewerBody) : the replacement reviewer and their assignment, nil to only unassign
+// Returns:
+// 	(error) : an error if one occurs
+func ControllerUnassignReviewer(submissionID uint, reviewerID string, reason string, editorID string, replacement *ReplaceReviewerBody) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		} else if isFinalStatus(submission.Status) {
+			return &SubmissionStatusFinalisedError{SubmissionID: submissionID}
+		} else if err := checkHandlingEditor(tx, submission, editorID); err != nil {
+			return err
+		} else if !isUserInList(reviewerID, submission.Reviewers) {
+			return &NotReviewerError{UserID: reviewerID, SubmissionID: submissionID}
+		}
+
+		// removes the reviewer and closes their invitation
+		if err := removeReviewer(tx, submissionID, reviewerID); err != nil {
+			return err
+		} else if err := tx.Model(&ReviewInvitation{}).
+			Where("submission_id = ? AND reviewer_id = ? AND status IN ?", submissionID, reviewerID,
+				[]string{INVITATION_INVITED, INVITATION_ACCEPTED}).
+			Update("status", INVITATION_REMOVED).Error; err != nil {
+			return err
+		}
+		removal := &ReviewerRemoval{SubmissionID: submissionID, ReviewerID: reviewerID, EditorID: editorID, Reason: reason}
+		if err := recordEvent(tx, submissionID, EVENT_REVIEWER_UNASSIGNED, editorID, true,
+			map[string]string{"reviewer": reviewerID, "reason": reason}); err != nil {
+			return err
+		}
+		if replacement != nil {
+			removal.ReplacementID = replacement.Replacement
+			if err := assignReviewersTx(tx, []string{replacement.Replacement}, submissionID,
+				replacement.Override, editorID, replacement.DueDate); err != nil {
+				return err
+			}
+		}
+
+		// archives the reviewer's review last, so that the database changes are
+		// rolled back if the metadata cannot be written
+		var err error
+		if submission.MetaData, err = getSubmissionMetaData(submissionID); err != nil {
+			return err
+		}
+		removal.ReviewArchived = archiveReviews(submission, reviewerID, reason, time.Now())
+		if err := tx.Create(removal).Error; err != nil {
+			return err
+		} else if removal.ReviewArchived {
+			return addMetaData(submission)
+		}
+		return nil
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// fills in the response for a failed reviewer removal or replacement, writing its status code
+func reassignmentErrorResponse(w http.ResponseWriter, err error, resp *AssignReviewersResponse, action string) {
+	switch err := err.(type) {
+	case *NoSubmissionError, *NotReviewerError:
+		w.WriteHeader(http.StatusNotFound)
+	case *SubmissionStatusFinalisedError, *BadUserError, *WrongPermissionsError:
+		w.WriteHeader(http.StatusBadRequest)
+	case *NotHandlingEditorError:
+		w.WriteHeader(http.StatusUnauthorized)
+	case *ConflictOfInterestError: // the replacement has conflicts of interest, which the editor did not override
+		resp.Conflicts = err.Conflicts
+		w.WriteHeader(http.StatusConflict)
+	default: // Unexpected error - error out as server error.
+		log.Printf("[ERROR] %s: %v\n", action, err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - " + action, Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+}
+
+// Archives an unassigned reviewer's reviews of a submission's current
+// version, so that they no longer count but are kept for editors.
+//
+// Params:
+// 	submission (*Submission) : the submission, with its metadata set
+// 	reviewerID (string) : the unassigned reviewer
+// 	reason (string) : the reason the reviewer was unassigned
+// 	archivedAt (time.Time) : the time the reviewer was unassigned
+// Returns:
+// 	(bool) : true if a review was archived
+func archiveReviews(submission *Submission, reviewerID string, reason string, archivedAt time.Time) bool {
+	archived := false
+	for _, review := range currentRoundReviews(submission) {
+		if review.ReviewerID == reviewerID {
+			review.ArchivedAt = &archivedAt
+			review.ArchiveReason = reason
+			archived = true
+		}
+	}
+	return archived
+}
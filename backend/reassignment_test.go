@@ -0,0 +1,51 @@
+// =====================================
+// reassignment_test.go
+// Created: October 19, 2026
+//
+// test file for reassignment.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that only the unassigned reviewer's current reviews are archived
+func TestArchiveReviews(t *testing.T) {
+	withdrawnAt := time.Now()
+	submission := &Submission{
+		Version: 2,
+		MetaData: &SubmissionData{Reviews: []*Review{
+			{ReviewerID: "removed", Round: 1},
+			{ReviewerID: "removed", Round: 2},
+			{ReviewerID: "kept", Round: 2},
+			{ReviewerID: "removed", Round: 2, WithdrawnAt: &withdrawnAt},
+		}},
+	}
+	archivedAt := time.Now()
+	if !assert.True(t, archiveReviews(submission, "removed", "unresponsive", archivedAt), "review not archived") {
+		return
+	}
+	reviews := submission.MetaData.Reviews
+	switch {
+	case !assert.Nil(t, reviews[0].ArchivedAt, "previous round archived"),
+		!assert.Equal(t, &archivedAt, reviews[1].ArchivedAt, "current review not archived"),
+		!assert.Equal(t, "unresponsive", reviews[1].ArchiveReason, "reason not recorded"),
+		!assert.Nil(t, reviews[2].ArchivedAt, "other reviewer's review archived"),
+		!assert.Nil(t, reviews[3].ArchivedAt, "withdrawn review archived"),
+		!assert.Len(t, currentRoundReviews(submission), 1, "archived review still counted"):
+		return
+	}
+	assert.False(t, archiveReviews(submission, "removed", "again", archivedAt), "review archived twice")
+
+	hideReviewHistory(submission.MetaData)
+	assert.Len(t, submission.MetaData.Reviews, 2, "archived review shown")
+}
@@ -0,0 +1,235 @@
+// =========================================================================
+// recommendations.go
+// Created: October 19, 2026
+//
+// This file takes care of suggesting reviewers for a submission, ranked by
+// how closely their past work matches the submission's tags and by how many
+// reviews they already have open
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_SUGGESTED_REVIEWERS = "/suggested-reviewers"
+
+	SUGGESTED_REVIEWERS_LIMIT = 10 // default number of suggested reviewers returned
+	REVIEWED_TAG_WEIGHT       = 2  // weight of a matching tag on a submission the reviewer reviewed
+	AUTHORED_TAG_WEIGHT       = 1  // weight of a matching tag on a submission the reviewer authored
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to get the reviewers best suited to review a submission
+// GET /submission/{id}/suggested-reviewers
+func GetSuggestedReviewers(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSuggestedReviewersResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	limit, limitErr := SUGGESTED_REVIEWERS_LIMIT, error(nil)
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, limitErr = strconv.Atoi(limitParam)
+	}
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to get suggested reviewers.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if limitErr != nil || limit < 1 {
+		err = &BadQueryParameterError{ParamName: "limit", Value: r.URL.Query().Get("limit")}
+		resp.StandardResponse = StandardResponse{Message: fmt.Sprintf("Bad Request - %s", err.Error()), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.Reviewers, err = getSuggestedReviewers(submissionID, limit); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not suggest reviewers: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not suggest reviewers", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Gets the reviewers best suited to review a submission, leaving out those
+// already assigned to it and those with a conflict of interest.
+//
+// Params:
+// 	submissionID (uint) : the submission reviewers are suggested for
+// 	limit (int) : the maximum number of reviewers suggested
+// Returns:
+// 	([]SuggestedReviewer) : suggested reviewers, best suited first
+// 	(error) : an error if one occurs
+func getSuggestedReviewers(submissionID uint, limit int) ([]SuggestedReviewer, error) {
+	suggestions := []SuggestedReviewer{}
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Categories").Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+
+		// gets the eligible reviewers not yet assigned, with their past submissions
+		candidates := []GlobalUser{}
+		if err := tx.Preload("AuthoredSubmissions.Categories").Preload("ReviewedSubmissions.Categories").
+			Where("user_type IN ?", []int{USERTYPE_REVIEWER, USERTYPE_REVIEWER_PUBLISHER}).
+			Find(&candidates).Error; err != nil {
+			return err
+		}
+		unassigned := []GlobalUser{}
+		candidateIDs := []string{}
+		for _, candidate := range candidates {
+			if !isUserInList(candidate.ID, submission.Reviewers) {
+				unassigned = append(unassigned, candidate)
+				candidateIDs = append(candidateIDs, candidate.ID)
+			}
+		}
+		if len(unassigned) == 0 {
+			return nil
+		}
+
+		conflicts, err := findConflicts(tx, submissionID, candidateIDs)
+		if err != nil {
+			return err
+		}
+		suggestions = rankReviewers(submission, unassigned, conflicts, limit)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return suggestions, nil
+}
+
+// Ranks reviewers for a submission. Each reviewer scores the submission's tags
+// found on the submissions they reviewed or authored, reviewed ones weighing
+// more, and the score is divided by the number of reviews they have open.
+// Reviewers with a conflict of interest are left out.
+//
+// Params:
+// 	submission (*Submission) : the submission, with its tags set
+// 	reviewers ([]GlobalUser) : the reviewers to rank, with their past submissions and their tags set
+// 	conflicts ([]ConflictReason) : the conflicts of interest of the reviewers with the submission
+// 	limit (int) : the maximum number of reviewers returned
+// Returns:
+// 	([]SuggestedReviewer) : the best suited reviewers, ordered by decreasing score
+func rankReviewers(submission *Submission, reviewers []GlobalUser, conflicts []ConflictReason, limit int) []SuggestedReviewer {
+	conflicted := make(map[string]bool)
+	for _, conflict := range conflicts {
+		conflicted[conflict.ReviewerID] = true
+	}
+	tags := make(map[string]bool)
+	for _, tag := range getTagArray(submission.Categories) {
+		tags[tag] = true
+	}
+
+	// counts the past submissions sharing tags with the submission
+	matchingTags := func(submissions []Submission, matched map[string]bool) int {
+		count := 0
+		for _, past := range submissions {
+			if past.ID == submission.ID {
+				continue
+			}
+			found := false
+			for _, tag := range getTagArray(past.Categories) {
+				if tags[tag] {
+					matched[tag] = true
+					found = true
+				}
+			}
+			if found {
+				count++
+			}
+		}
+		return count
+	}
+
+	suggestions := []SuggestedReviewer{}
+	for _, reviewer := range reviewers {
+		if conflicted[reviewer.ID] {
+			continue
+		}
+		matched := make(map[string]bool)
+		reviewed := matchingTags(reviewer.ReviewedSubmissions, matched)
+		authored := matchingTags(reviewer.AuthoredSubmissions, matched)
+		openReviews := 0
+		for _, assigned := range reviewer.ReviewedSubmissions {
+			if assigned.ID != submission.ID && assigned.Status != STATUS_DRAFT && !isFinalStatus(assigned.Status) {
+				openReviews++
+			}
+		}
+
+		suggestion := SuggestedReviewer{
+			ReviewerID:   reviewer.ID,
+			FirstName:    reviewer.FirstName,
+			LastName:     reviewer.LastName,
+			Score:        float64(REVIEWED_TAG_WEIGHT*reviewed+AUTHORED_TAG_WEIGHT*authored) / float64(1+openReviews),
+			MatchingTags: []string{},
+			OpenReviews:  openReviews,
+		}
+		for tag := range matched {
+			suggestion.MatchingTags = append(suggestion.MatchingTags, tag)
+		}
+		sort.Strings(suggestion.MatchingTags)
+		suggestion.Explanation = explainSuggestion(reviewed, authored, suggestion.MatchingTags, openReviews)
+		suggestions = append(suggestions, suggestion)
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		} else if suggestions[i].OpenReviews != suggestions[j].OpenReviews {
+			return suggestions[i].OpenReviews < suggestions[j].OpenReviews
+		}
+		return suggestions[i].ReviewerID < suggestions[j].ReviewerID
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions
+}
+
+// gives a short explanation of why a reviewer is suggested
+func explainSuggestion(reviewed int, authored int, matchingTags []string, openReviews int) string {
+	explanation := "no past submissions with matching tags"
+	if len(matchingTags) > 0 {
+		explanation = fmt.Sprintf("reviewed %d and authored %d submission(s) tagged %s",
+			reviewed, authored, strings.Join(matchingTags, ", "))
+	}
+	return fmt.Sprintf("%s; %d open review(s)", explanation, openReviews)
+}
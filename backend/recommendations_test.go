@@ -0,0 +1,49 @@
+// =====================================
+// recommendations_test.go
+// Created: October 19, 2026
+//
+// test file for recommendations.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that reviewers are ranked by matching tags and open reviews, without conflicts
+func TestRankReviewers(t *testing.T) {
+	python := []Category{{Tag: "python"}}
+	submission := &Submission{Model: gorm.Model{ID: 1}, Categories: []Category{{Tag: "python"}, {Tag: "networking"}}}
+	pastPython := Submission{Model: gorm.Model{ID: 2}, Status: STATUS_ACCEPTED, Categories: python}
+	openPython := Submission{Model: gorm.Model{ID: 3}, Status: STATUS_UNDER_REVIEW, Categories: python}
+	networking := Submission{Model: gorm.Model{ID: 4}, Status: STATUS_REJECTED, Categories: []Category{{Tag: "networking"}}}
+	reviewers := []GlobalUser{
+		{ID: "busy", ReviewedSubmissions: []Submission{pastPython, openPython}},
+		{ID: "expert", ReviewedSubmissions: []Submission{pastPython}, AuthoredSubmissions: []Submission{networking}},
+		{ID: "new"},
+		{ID: "conflicted", ReviewedSubmissions: []Submission{pastPython, networking}},
+	}
+	conflicts := []ConflictReason{{ReviewerID: "conflicted", Kind: CONFLICT_DECLARED}}
+
+	suggestions := rankReviewers(submission, reviewers, conflicts, 10)
+	switch {
+	case !assert.Len(t, suggestions, 3, "conflicted reviewer suggested"),
+		!assert.Equal(t, SuggestedReviewer{ReviewerID: "expert", Score: 3, MatchingTags: []string{"networking", "python"},
+			Explanation: "reviewed 1 and authored 1 submission(s) tagged networking, python; 0 open review(s)"},
+			suggestions[0], "wrong best suggestion"),
+		!assert.Equal(t, "busy", suggestions[1].ReviewerID, "wrong ranking"),
+		!assert.Equal(t, 1, suggestions[1].OpenReviews, "open reviews not counted"),
+		!assert.Equal(t, 2.0, suggestions[1].Score, "score not divided by open reviews"),
+		!assert.Equal(t, "no past submissions with matching tags; 0 open review(s)", suggestions[2].Explanation, "wrong explanation"):
+		return
+	}
+	assert.Len(t, rankReviewers(submission, reviewers, conflicts, 1), 1, "limit not applied")
+}
@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // ----------
 // Authentication/User Endpoints
 // ----------
@@ -35,6 +37,11 @@ type ChangePermissionsPostBody struct {
 	Permissions int `json:"permissions" validate:"min=0,max=4"`
 }
 
+// POST /user/{id}/chiefeditor
+type SetChiefEditorBody struct {
+	ChiefEditor bool `json:"chiefEditor"` // true to grant the role, false to revoke it
+}
+
 // ----------
 // Submissions Endpoints
 // ----------
@@ -55,6 +62,7 @@ type UploadSubmissionByZipBody struct {
 	Tags      []string `json:"tags"`
 	Authors   []string `json:"authors" validate:"required"`
 	Reviewers []string `json:"reviewers"`
+	Draft     bool     `json:"draft"` // drafts are only visible to their authors until submitted
 }
 
 // POST /submissions/create body
@@ -67,6 +75,7 @@ type UploadSubmissionBody struct {
 	Reviewers []string `json:"reviewers"`
 	Files     []File   `json:"files"`
 	Runnable  bool     `json:"runnable"`
+	Draft     bool     `json:"draft"`
 }
 
 // ----------
@@ -75,29 +84,130 @@ type UploadSubmissionBody struct {
 
 // POST /submissions/{id}/assignreviewers
 type AssignReviewersBody struct {
-	Reviewers []string `json:"reviewers" validate:"min=1"`
+	Reviewers []string   `json:"reviewers" validate:"min=1"`
+	Override  bool       `json:"override"`          // assigns the reviewers despite conflicts of interest, which gets recorded
+	DueDate   *time.Time `json:"dueDate,omitempty"` // deadline for the reviews, the journal's review period from now if not set
+}
+
+// POST /submission/{id}/unassignreviewer
+type UnassignReviewerBody struct {
+	Reviewer string `json:"reviewer" validate:"required"`
+	Reason   string `json:"reason" validate:"required,max=512"`
+}
+
+// POST /submission/{id}/replacereviewer
+type ReplaceReviewerBody struct {
+	Reviewer    string     `json:"reviewer" validate:"required"`
+	Replacement string     `json:"replacement" validate:"required,nefield=Reviewer"`
+	Reason      string     `json:"reason" validate:"required,max=512"`
+	Override    bool       `json:"override"`          // assigns the replacement despite conflicts of interest, which gets recorded
+	DueDate     *time.Time `json:"dueDate,omitempty"` // deadline for the replacement's review, the journal's review period from now if not set
+}
+
+// POST /submission/{id}/invitation/decline
+type DeclineInvitationBody struct {
+	Reason string `json:"reason" validate:"required,max=512"`
+}
+
+// POST /submission/{id}/declareconflict
+type DeclareConflictBody struct {
+	Reason string `json:"reason" validate:"required,max=512"`
 }
 
 // POST /submissions/{id}/review
 type UploadReviewBody struct {
-	Approved    bool   `json:"approved"`
-	Base64Value string `json:"base64Value" validate:"required"`
+	Approved    bool             `json:"approved"`
+	Base64Value string           `json:"base64Value" validate:"required"`
+	Scores      []CriterionScore `json:"scores" validate:"dive"` // required if a rubric is active for the submission
+	Named       bool             `json:"named"`                  // name the reviewer if the review is published
+}
+
+// POST /submission/{id}/publishreviews
+type PublishReviewsBody struct {
+	Publish bool `json:"publish"`
+}
+
+// POST /submission/{id}/review/identity
+type ReviewIdentityBody struct {
+	Named bool `json:"named"`
 }
 
 // POST /submissions/{id}/approve
 type UpdateSubmissionStatusBody struct {
-	Status bool `json:"status"`
+	Status        bool   `json:"status"`
+	Justification string `json:"justification,omitempty" validate:"max=2048"` // required to accept under the editor_override policy
+	DecisionLetterBody
+}
+
+// POST /submission/{id}/status
+type ChangeSubmissionStatusBody struct {
+	Status        string `json:"status" validate:"required,oneof=submitted under_review revisions_requested accepted rejected withdrawn"`
+	Justification string `json:"justification,omitempty" validate:"max=2048"` // recorded with accept and reject decisions
+	DecisionLetterBody
+}
+
+// POST /submission/{id}/appeal
+type AppealBody struct {
+	Statement string         `json:"statement,omitempty" validate:"max=65535"`
+	Rebuttals []RebuttalBody `json:"rebuttals" validate:"required,min=1,dive"`
+}
+
+// rebuttal of a review in a POST /submission/{id}/appeal body
+type RebuttalBody struct {
+	Reviewer string `json:"reviewer" validate:"required"` // the reviewer's ID, or their alias under blind review
+	Rebuttal string `json:"rebuttal" validate:"required,max=65535"`
+}
+
+// POST /submission/{id}/appeal/resolve
+type ResolveAppealBody struct {
+	Reopen     bool   `json:"reopen"` // true to reopen the submission, false to uphold its rejection
+	Resolution string `json:"resolution" validate:"required,max=65535"`
+}
+
+// POST /submission/{id}/handlingeditor
+type AssignHandlingEditorBody struct {
+	Editor string `json:"editor" validate:"required"` // ID of the editor to put in charge
+}
+
+// decision letter of a POST /submission/{id}/approve or /status body
+type DecisionLetterBody struct {
+	Letter     string     `json:"letter,omitempty" validate:"max=65535"` // placeholders are filled in
+	TemplateID uint       `json:"templateId,omitempty"`                  // template used if no letter is written
+	Deadline   *time.Time `json:"deadline,omitempty"`
+}
+
+// POST /submission/{id}/reviewmode
+type ChangeReviewModeBody struct {
+	ReviewMode string `json:"reviewMode" validate:"omitempty,oneof=open single_blind double_blind"` // empty to use the journal's
+}
+
+// POST /submission/{id}/resubmit
+type ResubmitSubmissionBody struct {
+	ZipBase64Value string `json:"base64" validate:"base64,required"`
+}
+
+// ----------
+// Files Endpoints
+// ----------
+
+// GET /file/{id} query parameters, selecting the file's comment threads
+type CommentThreadQuery struct {
+	Resolved bool // true to include resolved threads
+	Page     int  // numbered from 1
+	PageSize int  // 0 for every thread
 }
 
 // ----------
 // Comments Endpoints
 // ----------
 
-// POST /file/{id}/comment body. {id} in the URL is the file id
+// POST /file/{id}/comment body. {id} in the URL is the file id. Lines are
+// numbered from 1, lines 0 to 0 commenting on the whole file. Replies take
+// the lines of the comment they answer
 type NewCommentPostBody struct {
 	ParentID    *uint  `json:"parentId,omitempty"` // optionally set for replies
 	StartLine   int    `json:"startLine" validate:"min=0"`
-	EndLine     int    `json:"endLine" validate:"min=0"`
+	EndLine     int    `json:"endLine" validate:"min=0,gtefield=StartLine"`
 	Base64Value string `json:"base64Value" validate:"required"`
 }
 
@@ -106,6 +216,87 @@ type EditCommentPostBody struct {
 	Base64Value string `json:"base64Value" validate:"required"`
 }
 
+// POST /file/{id}/comment/{commentId}/react body
+type ReactCommentPostBody struct {
+	Emoji string `json:"emoji" validate:"required"` // one of the allowed reactions, toggled for the user
+}
+
+// ----------
+// Settings Endpoints
+// ----------
+
+// POST /settings/edit body. Empty fields are left unchanged
+type EditJournalSettingsBody struct {
+	SecretScanPolicy string `json:"secretScanPolicy,omitempty" validate:"omitempty,oneof=block report"`
+
+	BinaryFilePolicy     string `json:"binaryFilePolicy,omitempty" validate:"omitempty,oneof=allow attachment reject"`
+	ExecutableFilePolicy string `json:"executableFilePolicy,omitempty" validate:"omitempty,oneof=allow attachment reject"`
+	ArchiveFilePolicy    string `json:"archiveFilePolicy,omitempty" validate:"omitempty,oneof=allow attachment reject"`
+	OversizedFilePolicy  string `json:"oversizedFilePolicy,omitempty" validate:"omitempty,oneof=allow attachment reject"`
+	MaxFileSize          *int64 `json:"maxFileSize,omitempty" validate:"omitempty,min=0"` // pointer so that 0 (no limit) can be set
+
+	ReviewMode        string `json:"reviewMode,omitempty" validate:"omitempty,oneof=open single_blind double_blind"`
+	RedactAuthorNames *bool  `json:"redactAuthorNames,omitempty"` // pointer so that false can be set
+
+	CoauthorConflictYears *int `json:"coauthorConflictYears,omitempty" validate:"omitempty,min=0"` // pointer so that 0 can be set
+
+	ReviewPeriodDays     int  `json:"reviewPeriodDays,omitempty" validate:"min=0"`
+	InvitationExpiryDays int  `json:"invitationExpiryDays,omitempty" validate:"min=0"`
+	ReminderDays         *int `json:"reminderDays,omitempty" validate:"omitempty,min=0"` // pointer so that reminders can be disabled
+
+	AcceptancePolicy string `json:"acceptancePolicy,omitempty" validate:"omitempty,oneof=unanimous majority min_approvals editor_override"`
+	MinApprovals     int    `json:"minApprovals,omitempty" validate:"min=0"`
+
+	PublishReviews *bool `json:"publishReviews,omitempty"` // pointer so that false can be set
+}
+
+// POST /settings/policies/set
+type SetAcceptancePolicyBody struct {
+	Tag          string `json:"tag" validate:"required"`
+	Policy       string `json:"policy" validate:"required,oneof=unanimous majority min_approvals editor_override"`
+	MinApprovals int    `json:"minApprovals" validate:"min=0"`
+}
+
+// POST /settings/policies/delete
+type DeleteAcceptancePolicyBody struct {
+	Tag string `json:"tag" validate:"required"`
+}
+
+// POST /settings/templates/create and /settings/templates/{id}/edit
+type LetterTemplateBody struct {
+	Name string `json:"name" validate:"required,max=128"`
+	Body string `json:"body" validate:"required,max=65535"`
+}
+
+// ----------
+// Rubrics Endpoints
+// ----------
+
+// POST /rubrics/create body
+type CreateRubricBody struct {
+	Name     string                `json:"name" validate:"required,max=128"`
+	Tag      string                `json:"tag,omitempty"` // empty for a journal-wide rubric
+	Criteria []CreateCriterionBody `json:"criteria" validate:"required,min=1,dive"`
+}
+
+// criterion of a POST /rubrics/create body
+type CreateCriterionBody struct {
+	Name                  string `json:"name" validate:"required,max=64"`
+	Description           string `json:"description"`
+	MinScore              int    `json:"minScore" validate:"min=0"`
+	MaxScore              int    `json:"maxScore" validate:"gtfield=MinScore"`
+	RequiresJustification bool   `json:"requiresJustification"`
+}
+
+// ----------
+// Notifications Endpoints
+// ----------
+
+// POST /notifications/read body
+type ReadNotificationsBody struct {
+	IDs []uint `json:"ids,omitempty"` // notifications to mark as read, all if empty
+}
+
 // ----------
 // Journal Endpoints
 // ----------
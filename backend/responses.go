@@ -1,5 +1,7 @@
 package main
 
+import "time"
+
 // ----------
 // Authentication/User Endpoints
 // ----------
@@ -40,6 +42,115 @@ type UploadSubmissionResponse struct {
 	SubmissionID uint `json:"ID"`
 }
 
+// POST /submission/{id}/resubmit
+type ResubmitSubmissionResponse struct {
+	StandardResponse
+	Version uint `json:"version"`
+}
+
+// GET /submission/{id}/secrets
+type GetSubmissionSecretsResponse struct {
+	StandardResponse
+	Findings []SecretFinding `json:"findings"`
+}
+
+// GET /submission/{id}/vulnerabilities
+type GetSubmissionVulnerabilitiesResponse struct {
+	StandardResponse
+	Vulnerabilities []Vulnerability `json:"vulnerabilities"`
+}
+
+// GET /submission/{id}/similar
+type GetSimilarSubmissionsResponse struct {
+	StandardResponse
+	Submissions []SimilarSubmission `json:"submissions"`
+}
+
+// submission sharing code with the submission being compared
+type SimilarSubmission struct {
+	SubmissionID  uint               `json:"submissionId"`
+	Name          string             `json:"name"`
+	Overlap       float64            `json:"overlap"` // percentage of the compared submission's fingerprints found
+	MatchingFiles []MatchingFilePair `json:"matchingFiles"`
+}
+
+// pair of files sharing code between two submissions
+type MatchingFilePair struct {
+	FileID      uint    `json:"fileId"`
+	Path        string  `json:"path"`
+	OtherFileID uint    `json:"otherFileId"`
+	OtherPath   string  `json:"otherPath"`
+	Overlap     float64 `json:"overlap"` // percentage of the file's fingerprints found in the other file
+}
+
+// POST /submission/{id}/assignreviewers
+type AssignReviewersResponse struct {
+	StandardResponse
+	Conflicts []ConflictReason `json:"conflicts,omitempty"` // set if reviewers were not assigned due to conflicts of interest
+}
+
+// conflict of interest between a proposed reviewer and a submission
+type ConflictReason struct {
+	ReviewerID string `json:"reviewerId"`
+	Kind       string `json:"kind"` // author, organization, coauthor or declared
+	Detail     string `json:"detail"`
+}
+
+// GET /submission/{id}/suggested-reviewers
+type GetSuggestedReviewersResponse struct {
+	StandardResponse
+	Reviewers []SuggestedReviewer `json:"reviewers"`
+}
+
+// reviewer suggested for a submission, with the reasons they are suggested
+type SuggestedReviewer struct {
+	ReviewerID   string   `json:"reviewerId"`
+	FirstName    string   `json:"firstName"`
+	LastName     string   `json:"lastName"`
+	Score        float64  `json:"score"`        // weighted count of past submissions with matching tags, divided by open reviews + 1
+	MatchingTags []string `json:"matchingTags"` // tags of the submission found on the reviewer's past submissions
+	OpenReviews  int      `json:"openReviews"`  // submissions assigned to the reviewer which are not yet decided
+	Explanation  string   `json:"explanation"`
+}
+
+// GET /submission/{id}/invitations, GET /submissions/overdue and GET /submissions/assignments
+type GetInvitationsResponse struct {
+	StandardResponse
+	Invitations []ReviewInvitation `json:"invitations"`
+	Removals    []ReviewerRemoval  `json:"removals,omitempty"` // reviewers unassigned by editors, only for a single submission
+}
+
+// GET /submission/{id}/conflicts
+type GetSubmissionConflictsResponse struct {
+	StandardResponse
+	Declarations []ConflictDeclaration `json:"declarations"`
+	Overrides    []ConflictOverride    `json:"overrides"`
+}
+
+// GET /submission/{id}/history
+type GetSubmissionHistoryResponse struct {
+	StandardResponse
+	Events []SubmissionEvent `json:"events"`
+}
+
+// POST /submission/{id}/appeal
+type FileAppealResponse struct {
+	StandardResponse
+	ID uint `json:"id"`
+}
+
+// GET /submission/{id}/appeals
+type GetAppealsResponse struct {
+	StandardResponse
+	Appeals []Appeal `json:"appeals"`
+}
+
+// GET /submission/{id}/decisions
+type GetDecisionsResponse struct {
+	StandardResponse
+	Decisions []SubmissionDecision `json:"decisions"`
+}
+
 // ----------
 // Files Endpoints
 // ----------
@@ -47,7 +158,9 @@ type UploadSubmissionResponse struct {
 // GET /file/{id} body
 type GetFileResponse struct {
 	StandardResponse
-	File *File `json:"file"`
+	File              *File        `json:"file"`
+	Threads           int          `json:"threads"`           // number of comment threads shown across all pages
+	UnresolvedThreads map[uint]int `json:"unresolvedThreads"` // open comment threads of each file of the submission
 }
 
 // ----------
@@ -60,6 +173,156 @@ type NewCommentResponse struct {
 	ID uint `json:"id"`
 }
 
+// POST /file/{id}/comment/{commentId}/react
+type ReactCommentResponse struct {
+	StandardResponse
+	Reacted bool `json:"reacted"` // true if the reaction was added, false if it was removed
+}
+
+// ----------
+// Settings Endpoints
+// ----------
+
+// GET /settings
+type GetJournalSettingsResponse struct {
+	StandardResponse
+	Settings *JournalSettings `json:"settings,omitempty"`
+}
+
+// GET /settings/policies
+type GetAcceptancePoliciesResponse struct {
+	StandardResponse
+	Policies []AcceptancePolicy `json:"policies"`
+}
+
+// GET /settings/templates
+type GetLetterTemplatesResponse struct {
+	StandardResponse
+	Templates []LetterTemplate `json:"templates"`
+}
+
+// POST /settings/templates/create
+type CreateLetterTemplateResponse struct {
+	StandardResponse
+	ID uint `json:"id"`
+}
+
+// GET /submission/{id}/letter
+type GetDecisionLetterResponse struct {
+	StandardResponse
+	Letter string `json:"letter"`
+}
+
+// ----------
+// Analytics Endpoints
+// ----------
+
+// GET /analytics/queue
+type GetSubmissionQueueResponse struct {
+	StandardResponse
+	Queue []QueueStatus `json:"queue"`
+}
+
+// submissions waiting in a status
+type QueueStatus struct {
+	Status      string       `json:"status"`
+	Count       int          `json:"count"`
+	Submissions []QueueEntry `json:"submissions"`
+}
+
+// submission waiting in a status, with the time it has waited
+type QueueEntry struct {
+	SubmissionID     uint      `json:"submissionId"`
+	SubmissionName   string    `json:"submissionName"`
+	HandlingEditorID *string   `json:"handlingEditorId,omitempty"`
+	EnteredAt        time.Time `json:"enteredAt"`
+	AgeHours         float64   `json:"ageHours"`
+}
+
+// GET /analytics/reviewers
+type GetReviewerWorkloadsResponse struct {
+	StandardResponse
+	Reviewers []ReviewerWorkload `json:"reviewers"`
+}
+
+// reviews of a reviewer, by state
+type ReviewerWorkload struct {
+	ReviewerID string `json:"reviewerId"`
+	FirstName  string `json:"firstName"`
+	LastName   string `json:"lastName"`
+	Open       int    `json:"open"`
+	Completed  int    `json:"completed"`
+	Overdue    int    `json:"overdue"` // open reviews past their deadline
+}
+
+// GET /analytics/turnaround
+type GetTurnaroundResponse struct {
+	StandardResponse
+	From              time.Time    `json:"from"`
+	To                time.Time    `json:"to"`
+	TimeToFirstReview Distribution `json:"timeToFirstReview"`
+	TimeToDecision    Distribution `json:"timeToDecision"`
+}
+
+// distribution of durations, in hours
+type Distribution struct {
+	Count       int                  `json:"count"`
+	MinHours    float64              `json:"minHours"`
+	MedianHours float64              `json:"medianHours"`
+	P90Hours    float64              `json:"p90Hours"`
+	MaxHours    float64              `json:"maxHours"`
+	MeanHours   float64              `json:"meanHours"`
+	Buckets     []DistributionBucket `json:"buckets"`
+}
+
+// number of durations in a range of days
+type DistributionBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// ----------
+// Rubrics Endpoints
+// ----------
+
+// GET /rubrics
+type GetRubricsResponse struct {
+	StandardResponse
+	Rubrics []Rubric `json:"rubrics"`
+}
+
+// POST /rubrics/create
+type CreateRubricResponse struct {
+	StandardResponse
+	ID uint `json:"id"`
+}
+
+// GET /submission/{id}/rubric
+type GetSubmissionRubricResponse struct {
+	StandardResponse
+	Rubric *Rubric `json:"rubric"` // nil if no rubric is active for the submission
+}
+
+// scores given to a rubric criterion by a submission's reviews
+type CriterionAggregate struct {
+	CriterionID uint    `json:"criterionId"`
+	Criterion   string  `json:"criterion"`
+	Count       int     `json:"count"`
+	Mean        float64 `json:"mean"`
+	Min         int     `json:"min"`
+	Max         int     `json:"max"`
+}
+
+// ----------
+// Notifications Endpoints
+// ----------
+
+// GET /notifications
+type GetNotificationsResponse struct {
+	StandardResponse
+	Notifications []Notification `json:"notifications"`
+}
+
 // ----------
 // Journal Endpoints
 // ----------
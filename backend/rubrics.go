@@ -0,0 +1,376 @@
+// =========================================================================
+// rubrics.go
+// Created: October 19, 2026
+//
+// This file takes care of review rubrics: the scored criteria editors define
+// journal-wide or per tag, the validation of reviews' scores against them
+// and the aggregation of scores given to a submission
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	SUBROUTE_RUBRICS = "/rubrics"
+
+	ENDPOINT_CREATE_RUBRIC     = "/create"
+	ENDPOINT_DEACTIVATE_RUBRIC = "/deactivate"
+	ENDPOINT_RUBRIC            = "/rubric"
+)
+
+// Describe mux routing for rubric endpoints.
+func getRubricsSubRoutes(r *mux.Router) {
+	rubrics := r.PathPrefix(SUBROUTE_RUBRICS).Subrouter()
+	rubrics.Use(jwtMiddleware)
+
+	// Rubrics routes:
+	// + GET /rubrics - Get all rubrics.
+	// + POST /rubrics/create - Create a rubric, replacing the active rubric for its tag.
+	// + POST /rubrics/{id}/deactivate - Stop using a rubric for new reviews.
+	rubrics.HandleFunc("", GetRubrics).Methods(http.MethodGet)
+	rubrics.HandleFunc(ENDPOINT_CREATE_RUBRIC, PostCreateRubric).Methods(http.MethodPost, http.MethodOptions)
+	rubrics.HandleFunc("/{id}"+ENDPOINT_DEACTIVATE_RUBRIC, PostDeactivateRubric).Methods(http.MethodPost, http.MethodOptions)
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to view all rubrics, newest first
+// GET /rubrics
+func GetRubrics(w http.ResponseWriter, r *http.Request) {
+	resp := &GetRubricsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view rubrics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := gormDb.Preload("Criteria", orderCriteria).Order("id DESC").Find(&resp.Rubrics).Error; err != nil {
+		log.Printf("[ERROR] could not get rubrics: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get rubrics", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to create a rubric. The new rubric replaces
+// the active rubric for its tag (or the journal-wide one if it has no tag)
+// POST /rubrics/create
+func PostCreateRubric(w http.ResponseWriter, r *http.Request) {
+	resp := &CreateRubricResponse{}
+	reqBody := &CreateRubricBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to create rubrics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.ID, err = ControllerCreateRubric(reqBody); err != nil {
+		log.Printf("[ERROR] could not create rubric: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not create rubric", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else {
+		resp.StandardResponse = StandardResponse{Message: "Rubric created successfully", Error: false}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to stop a rubric being used for new reviews.
+// Reviews already scored against it keep their scores
+// POST /rubrics/{id}/deactivate
+func PostDeactivateRubric(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Rubric deactivated successfully", Error: false}
+
+	params := mux.Vars(r)
+	rubricID64, err := strconv.ParseUint(params["id"], 10, 32)
+	rubricID := uint(rubricID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Rubric ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to deactivate rubrics.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if res := gormDb.Model(&Rubric{}).Where("id = ?", rubricID).Update("active", false); res.Error != nil {
+		log.Printf("[ERROR] could not deactivate rubric: %v\n", res.Error)
+		resp = &StandardResponse{Message: "Internal Server Error - could not deactivate rubric", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else if res.RowsAffected == 0 {
+		// no rows are affected if the rubric is already inactive, hence checks it exists
+		if err := gormDb.First(&Rubric{}, rubricID).Error; err != nil {
+			resp = &StandardResponse{Message: (&NoRubricError{ID: rubricID}).Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function to get the rubric reviews of a submission are scored
+// against, for anyone who can view the submission
+// GET /submission/{id}/rubric
+func GetSubmissionRubric(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionRubricResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	ctx, _ := r.Context().Value("data").(*RequestContext)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.Rubric, err = getSubmissionRubric(submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Not authorized to access the given submission", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] could not get submission rubric: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get submission rubric", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which creates a rubric and makes it the active rubric for its
+// tag, deactivating the previous one.
+//
+// Params:
+// 	r (*CreateRubricBody) : the rubric's name, tag and criteria
+// Returns:
+// 	(uint) : the ID of the new rubric
+// 	(error) : an error if one occurs
+func ControllerCreateRubric(r *CreateRubricBody) (uint, error) {
+	rubric := &Rubric{Name: r.Name, Tag: strings.TrimSpace(r.Tag), Active: true}
+	for _, criterion := range r.Criteria {
+		rubric.Criteria = append(rubric.Criteria, RubricCriterion{
+			Name:                  criterion.Name,
+			Description:           criterion.Description,
+			MinScore:              criterion.MinScore,
+			MaxScore:              criterion.MaxScore,
+			RequiresJustification: criterion.RequiresJustification,
+		})
+	}
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&Rubric{}).Where("tag = ? AND active = ?", rubric.Tag, true).Update("active", false).Error; err != nil {
+			return err
+		}
+		return tx.Create(rubric).Error
+	}); err != nil {
+		return 0, err
+	}
+	return rubric.ID, nil
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the active rubric of a submission, checking that the user can view it
+func getSubmissionRubric(submissionID uint, ctx *RequestContext) (*Rubric, error) {
+	submission := &Submission{}
+	if res := gormDb.Preload("Authors").Preload("Reviewers").Preload("Categories").
+		Limit(1).Find(submission, submissionID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoSubmissionError{ID: submissionID}
+	} else if !canViewSubmission(submission, ctx) {
+		userID := ""
+		if ctx != nil {
+			userID = ctx.ID
+		}
+		return nil, &WrongPermissionsError{userID: userID}
+	}
+	return getActiveRubric(gormDb, submission)
+}
+
+// Gets the rubric new reviews of a submission are scored against: the most
+// recent active rubric for one of the submission's tags, else the active
+// journal-wide rubric.
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// 	submission (*Submission) : the submission, with its categories set
+// Returns:
+// 	(*Rubric) : the active rubric with its criteria, nil if there is none
+// 	(error) : an error if one occurs
+func getActiveRubric(tx *gorm.DB, submission *Submission) (*Rubric, error) {
+	tags := append(getTagArray(submission.Categories), "")
+	rubric := &Rubric{}
+	if res := tx.Preload("Criteria", orderCriteria).Where("active = ? AND tag IN ?", true, tags).
+		Order("tag = '' ASC, id DESC").Limit(1).Find(rubric); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, nil
+	}
+	return rubric, nil
+}
+
+// gets a rubric with its criteria by ID, nil if the ID is 0 (i.e. a review
+// uploaded while no rubric was active)
+func getRubric(tx *gorm.DB, rubricID uint) (*Rubric, error) {
+	if rubricID == 0 {
+		return nil, nil
+	}
+	rubric := &Rubric{}
+	if res := tx.Preload("Criteria", orderCriteria).Limit(1).Find(rubric, rubricID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoRubricError{ID: rubricID}
+	}
+	return rubric, nil
+}
+
+// orders a rubric's criteria in the order they were defined in
+func orderCriteria(tx *gorm.DB) *gorm.DB {
+	return tx.Order("id")
+}
+
+// Checks that a review's scores match a rubric: every criterion is scored
+// exactly once within its range, and justified if the criterion requires it.
+//
+// Params:
+// 	rubric (*Rubric) : the rubric the review is scored against, nil if there is none
+// 	scores ([]CriterionScore) : the review's scores
+// Returns:
+// 	(error) : a *BadReviewScoresError describing the first problem found
+func validateReviewScores(rubric *Rubric, scores []CriterionScore) error {
+	if rubric == nil {
+		if len(scores) > 0 {
+			return &BadReviewScoresError{Reason: "no rubric is active for this submission"}
+		}
+		return nil
+	}
+	criteria := make(map[uint]RubricCriterion)
+	for _, criterion := range rubric.Criteria {
+		criteria[criterion.ID] = criterion
+	}
+	scored := make(map[uint]bool)
+	for _, score := range scores {
+		criterion, ok := criteria[score.CriterionID]
+		if !ok {
+			return &BadReviewScoresError{Reason: fmt.Sprintf("criterion %d is not part of rubric %d", score.CriterionID, rubric.ID)}
+		} else if scored[score.CriterionID] {
+			return &BadReviewScoresError{Reason: fmt.Sprintf("%s is scored more than once", criterion.Name)}
+		} else if score.Score < criterion.MinScore || score.Score > criterion.MaxScore {
+			return &BadReviewScoresError{Reason: fmt.Sprintf("%s must be scored between %d and %d",
+				criterion.Name, criterion.MinScore, criterion.MaxScore)}
+		} else if criterion.RequiresJustification && strings.TrimSpace(score.Justification) == "" {
+			return &BadReviewScoresError{Reason: fmt.Sprintf("%s requires a justification", criterion.Name)}
+		}
+		scored[score.CriterionID] = true
+	}
+	for _, criterion := range rubric.Criteria {
+		if !scored[criterion.ID] {
+			return &BadReviewScoresError{Reason: fmt.Sprintf("%s is not scored", criterion.Name)}
+		}
+	}
+	return nil
+}
+
+// Aggregates the scores of a submission's reviews by criterion.
+//
+// Params:
+// 	reviews ([]*Review) : the reviews to aggregate (i.e. those of the current version)
+// Returns:
+// 	([]CriterionAggregate) : the aggregated scores, nil if no review is scored
+// 	(error) : an error if one occurs
+func getRubricScores(reviews []*Review) ([]CriterionAggregate, error) {
+	criterionIDs := []uint{}
+	for _, review := range reviews {
+		for _, score := range review.Scores {
+			criterionIDs = append(criterionIDs, score.CriterionID)
+		}
+	}
+	if len(criterionIDs) == 0 {
+		return nil, nil
+	}
+	criteria := []RubricCriterion{}
+	if err := gormDb.Where("id IN ?", criterionIDs).Order("id").Find(&criteria).Error; err != nil {
+		return nil, err
+	}
+	return aggregateScores(reviews, criteria), nil
+}
+
+// aggregates the scores given to each criterion by the reviews, in the order
+// of the criteria. Criteria no review scored are left out
+func aggregateScores(reviews []*Review, criteria []RubricCriterion) []CriterionAggregate {
+	aggregates := []CriterionAggregate{}
+	for _, criterion := range criteria {
+		aggregate := CriterionAggregate{CriterionID: criterion.ID, Criterion: criterion.Name}
+		total := 0
+		for _, review := range reviews {
+			for _, score := range review.Scores {
+				if score.CriterionID != criterion.ID {
+					continue
+				}
+				if aggregate.Count == 0 || score.Score < aggregate.Min {
+					aggregate.Min = score.Score
+				}
+				if aggregate.Count == 0 || score.Score > aggregate.Max {
+					aggregate.Max = score.Score
+				}
+				total += score.Score
+				aggregate.Count++
+			}
+		}
+		if aggregate.Count > 0 {
+			aggregate.Mean = float64(total) / float64(aggregate.Count)
+			aggregates = append(aggregates, aggregate)
+		}
+	}
+	return aggregates
+}
@@ -0,0 +1,76 @@
+// =====================================
+// rubrics_test.go
+// Created: October 19, 2026
+//
+// test file for rubrics.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rubric used for testing, with one criterion requiring a justification
+var testRubric = &Rubric{
+	Criteria: []RubricCriterion{
+		{ID: 1, Name: "Correctness", MinScore: 1, MaxScore: 5, RequiresJustification: true},
+		{ID: 2, Name: "Documentation", MinScore: 1, MaxScore: 5},
+	},
+}
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that review scores must match the rubric they are scored against
+func TestValidateReviewScores(t *testing.T) {
+	testCases := []struct {
+		name   string
+		rubric *Rubric
+		scores []CriterionScore
+		valid  bool
+	}{
+		{"valid", testRubric, []CriterionScore{{CriterionID: 1, Score: 4, Justification: "works"}, {CriterionID: 2, Score: 1}}, true},
+		{"no rubric", nil, nil, true},
+		{"scores without rubric", nil, []CriterionScore{{CriterionID: 1, Score: 4}}, false},
+		{"missing criterion", testRubric, []CriterionScore{{CriterionID: 1, Score: 4, Justification: "works"}}, false},
+		{"unknown criterion", testRubric, []CriterionScore{{CriterionID: 1, Score: 4, Justification: "works"},
+			{CriterionID: 2, Score: 1}, {CriterionID: 3, Score: 1}}, false},
+		{"duplicate criterion", testRubric, []CriterionScore{{CriterionID: 1, Score: 4, Justification: "works"},
+			{CriterionID: 2, Score: 1}, {CriterionID: 2, Score: 2}}, false},
+		{"out of range", testRubric, []CriterionScore{{CriterionID: 1, Score: 6, Justification: "works"}, {CriterionID: 2, Score: 1}}, false},
+		{"missing justification", testRubric, []CriterionScore{{CriterionID: 1, Score: 4, Justification: " "}, {CriterionID: 2, Score: 1}}, false},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			err := validateReviewScores(testCase.rubric, testCase.scores)
+			if testCase.valid {
+				assert.NoError(t, err, "valid scores rejected")
+			} else {
+				assert.IsType(t, &BadReviewScoresError{}, err, "invalid scores accepted")
+			}
+		})
+	}
+}
+
+// tests that scores are aggregated per criterion in the rubric's order
+func TestAggregateScores(t *testing.T) {
+	reviews := []*Review{
+		{ReviewerID: "a", Scores: []CriterionScore{{CriterionID: 2, Score: 2}, {CriterionID: 1, Score: 5}}},
+		{ReviewerID: "b", Scores: []CriterionScore{{CriterionID: 1, Score: 2}}},
+		{ReviewerID: "c"},
+	}
+	aggregates := aggregateScores(reviews, append(testRubric.Criteria, RubricCriterion{ID: 3, Name: "Quality"}))
+	switch {
+	case !assert.Len(t, aggregates, 2, "unscored criterion aggregated"),
+		!assert.Equal(t, CriterionAggregate{CriterionID: 1, Criterion: "Correctness", Count: 2, Mean: 3.5, Min: 2, Max: 5},
+			aggregates[0], "wrong aggregate"),
+		!assert.Equal(t, CriterionAggregate{CriterionID: 2, Criterion: "Documentation", Count: 1, Mean: 2, Min: 2, Max: 2},
+			aggregates[1], "wrong aggregate"):
+		return
+	}
+	assert.Empty(t, aggregateScores([]*Review{}, testRubric.Criteria), "scores aggregated without reviews")
+}
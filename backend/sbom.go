@@ -0,0 +1,224 @@
+// =========================================================================
+// sbom.go
+// Created: October 19, 2026
+//
+// This file takes care of exporting a submission's parsed dependencies as a
+// software bill of materials (CycloneDX or SPDX)
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	uuid "github.com/satori/go.uuid"
+)
+
+const (
+	ENDPOINT_SBOM = "/sbom"
+
+	SBOM_FORMAT_CYCLONEDX = "cyclonedx"
+	SBOM_FORMAT_SPDX      = "spdx"
+	SBOM_TOOL_NAME        = "Tool: cs3099group11-backend"
+)
+
+// package URL types by ecosystem (see github.com/package-url/purl-spec)
+var purlTypes = map[string]string{
+	ECOSYSTEM_GO:    "golang",
+	ECOSYSTEM_PYPI:  "pypi",
+	ECOSYSTEM_NPM:   "npm",
+	ECOSYSTEM_CARGO: "cargo",
+	ECOSYSTEM_MAVEN: "maven",
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function to export a submission's dependencies as an SBOM. The format
+// is given by the format query parameter (cyclonedx by default)
+// GET /submission/{id}/sbom
+func GetSubmissionSbom(w http.ResponseWriter, r *http.Request) {
+	var encodable interface{}
+
+	params := mux.Vars(r)
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = SBOM_FORMAT_CYCLONEDX
+	}
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	var submission *Submission
+	if err != nil {
+		encodable = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if format != SBOM_FORMAT_CYCLONEDX && format != SBOM_FORMAT_SPDX {
+		err = &BadQueryParameterError{ParamName: "format", Value: format}
+		encodable = &StandardResponse{Message: fmt.Sprintf("Bad Request - %s", err.Error()), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+		// gets the request context if there is a user logged in
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); ok && validate.Struct(ctx) != nil {
+		encodable = &StandardResponse{Message: "Bad Request Context", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if submission, err = getSubmission(uint(submissionID64)); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			encodable = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not retrieve submission for SBOM export: %v", err)
+			encodable = &StandardResponse{Message: "Internal Server Error - could not export SBOM", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+
+	} else if !canViewSubmission(submission, ctx) {
+		encodable = &StandardResponse{Message: "Not authorized to access the given submission", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if format == SBOM_FORMAT_SPDX {
+		w.Header().Set("Content-Type", "application/spdx+json")
+		encodable = buildSpdxDocument(submission)
+
+	} else {
+		w.Header().Set("Content-Type", "application/vnd.cyclonedx+json")
+		encodable = buildCycloneDxBom(submission)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(encodable); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Builds a CycloneDX bill of materials from a submission's dependencies.
+// Unpinned dependencies keep their version constraint as a property.
+func buildCycloneDxBom(submission *Submission) *CycloneDxBom {
+	bom := &CycloneDxBom{
+		BomFormat:    "CycloneDX",
+		SpecVersion:  "1.4",
+		SerialNumber: "urn:uuid:" + uuid.NewV4().String(),
+		Version:      1,
+		Metadata: CycloneDxMetadata{
+			Timestamp: time.Now().UTC().Format(time.RFC3339),
+			Component: CycloneDxComponent{
+				Type: "application", BomRef: fmt.Sprintf("submission-%d", submission.ID), Name: submission.Name,
+			},
+		},
+		Components: []CycloneDxComponent{},
+	}
+	for _, dependency := range submission.Dependencies {
+		component := CycloneDxComponent{
+			Type: "library", Name: dependency.Name, Purl: dependencyPurl(dependency),
+			Properties: []CycloneDxProperty{
+				{Name: "ecosystem", Value: dependency.Ecosystem},
+				{Name: "manifest", Value: dependency.Manifest},
+				{Name: "pinned", Value: strconv.FormatBool(dependency.Pinned)},
+			},
+		}
+		component.BomRef = fmt.Sprintf("%s#%d", component.Purl, dependency.ID)
+		if dependency.Ecosystem == ECOSYSTEM_MAVEN {
+			if parts := strings.SplitN(dependency.Name, ":", 2); len(parts) == 2 {
+				component.Group, component.Name = parts[0], parts[1]
+			}
+		}
+		if dependency.Pinned {
+			component.Version = dependency.Version
+		} else if dependency.Version != "" {
+			component.Properties = append(component.Properties, CycloneDxProperty{Name: "versionConstraint", Value: dependency.Version})
+		}
+		if dependency.Dev {
+			component.Scope = "optional"
+		}
+		bom.Components = append(bom.Components, component)
+	}
+	return bom
+}
+
+// Builds an SPDX document from a submission's dependencies, with the
+// submission as the described package depending on each dependency.
+func buildSpdxDocument(submission *Submission) *SpdxDocument {
+	license := submission.License
+	if license == "" {
+		license = "NOASSERTION"
+	}
+	rootID := "SPDXRef-Submission"
+	document := &SpdxDocument{
+		SpdxVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SpdxID:            "SPDXRef-DOCUMENT",
+		Name:              submission.Name,
+		DocumentNamespace: fmt.Sprintf("%s/spdx/submission-%d-%s", BACKEND_ADDRESS, submission.ID, uuid.NewV4().String()),
+		CreationInfo: SpdxCreationInfo{
+			Created:  time.Now().UTC().Format(time.RFC3339),
+			Creators: []string{SBOM_TOOL_NAME},
+		},
+		Packages: []SpdxPackage{{
+			SpdxID: rootID, Name: submission.Name, DownloadLocation: "NOASSERTION", LicenseConcluded: license,
+		}},
+		Relationships: []SpdxRelationship{
+			{SpdxElementID: "SPDXRef-DOCUMENT", RelatedSpdxElement: rootID, RelationshipType: "DESCRIBES"},
+		},
+	}
+	for i, dependency := range submission.Dependencies {
+		spdxPackage := SpdxPackage{
+			SpdxID:           fmt.Sprintf("SPDXRef-Package-%d", i+1),
+			Name:             dependency.Name,
+			DownloadLocation: "NOASSERTION",
+			Comment:          fmt.Sprintf("Declared in %s (pinned: %t)", dependency.Manifest, dependency.Pinned),
+			ExternalRefs: []SpdxExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: dependencyPurl(dependency)},
+			},
+		}
+		if dependency.Pinned {
+			spdxPackage.VersionInfo = dependency.Version
+		} else if dependency.Version != "" {
+			spdxPackage.Comment += ", version constraint: " + dependency.Version
+		}
+		relationship := "DEPENDS_ON"
+		if dependency.Dev {
+			relationship = "DEV_DEPENDENCY_OF"
+			document.Relationships = append(document.Relationships, SpdxRelationship{
+				SpdxElementID: spdxPackage.SpdxID, RelatedSpdxElement: rootID, RelationshipType: relationship,
+			})
+		} else {
+			document.Relationships = append(document.Relationships, SpdxRelationship{
+				SpdxElementID: rootID, RelatedSpdxElement: spdxPackage.SpdxID, RelationshipType: relationship,
+			})
+		}
+		document.Packages = append(document.Packages, spdxPackage)
+	}
+	return document
+}
+
+// Builds the package URL of a dependency. The version is only included for
+// pinned dependencies.
+func dependencyPurl(dependency Dependency) string {
+	name := dependency.Name
+	if dependency.Ecosystem == ECOSYSTEM_MAVEN {
+		name = strings.Replace(name, ":", "/", 1)
+	}
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+	}
+	purl := fmt.Sprintf("pkg:%s/%s", purlTypes[dependency.Ecosystem], strings.Join(segments, "/"))
+	if dependency.Pinned {
+		purl += "@" + url.PathEscape(dependency.Version)
+	}
+	return purl
+}
@@ -0,0 +1,300 @@
+// =========================================================================
+// scanning.go
+// Created: October 19, 2026
+//
+// This file takes care of scanning submission files for credentials
+// (cloud keys, private keys, tokens, passwords) before they are stored
+// =========================================================================
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_SECRETS = "/secrets"
+
+	SECRET_RULES_ENV = "SECRET_RULES_PATH" // path to a JSON rule set replacing the default one
+	RULE_ENTROPY     = "high-entropy-string"
+	PREVIEW_LENGTH   = 4 // number of characters of a secret kept in its preview
+)
+
+// Single credential rule, matched line by line against file content.
+type SecretRule struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+
+	matcher *regexp.Regexp
+}
+
+// Collection of rules a submission is scanned against.
+type SecretRuleSet struct {
+	Rules       []*SecretRule `json:"rules"`
+	IgnorePaths []string      `json:"ignorePaths"` // glob patterns (on base names) of files never scanned
+
+	// high entropy string detection
+	EntropyEnabled   bool    `json:"entropyEnabled"`
+	EntropyMinLength int     `json:"entropyMinLength"`
+	EntropyThreshold float64 `json:"entropyThreshold"`
+}
+
+// rule set currently in use, replaced on setup if a rule set file is configured
+var secretRules *SecretRuleSet = defaultSecretRuleSet()
+
+// characters which can make up a high entropy token (base64 and url-safe base64)
+var entropyTokenMatcher = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// gets the rule set used when no rule set file is configured
+func defaultSecretRuleSet() *SecretRuleSet {
+	ruleSet := &SecretRuleSet{
+		Rules: []*SecretRule{
+			{Name: "aws-access-key-id", Pattern: `\b(AKIA|ASIA|ABIA|ACCA|A3T[A-Z0-9])[A-Z0-9]{16}\b`},
+			{Name: "aws-secret-access-key", Pattern: `(?i)aws.{0,20}(secret|key).{0,5}['"\s:=]+[A-Za-z0-9/+=]{40}\b`},
+			{Name: "gcp-api-key", Pattern: `\bAIza[0-9A-Za-z\-_]{35}\b`},
+			{Name: "gcp-service-account", Pattern: `"type"\s*:\s*"service_account"`},
+			{Name: "private-key", Pattern: `-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY( BLOCK)?-----`},
+			{Name: "jwt", Pattern: `\beyJ[A-Za-z0-9_\-]{8,}\.eyJ[A-Za-z0-9_\-]{8,}\.[A-Za-z0-9_\-]{8,}`},
+			{Name: "password-assignment", Pattern: `(?i)^\s*(export\s+)?[A-Z0-9_]*(PASSWORD|PASSWD|SECRET|TOKEN|API_KEY)[A-Z0-9_]*\s*=\s*['"]?[^\s'"]{4,}`},
+		},
+		IgnorePaths:      []string{"go.sum", "package-lock.json", "yarn.lock", "Cargo.lock", "poetry.lock"},
+		EntropyEnabled:   true,
+		EntropyMinLength: 20,
+		EntropyThreshold: 4.5,
+	}
+	ruleSet.compile()
+	return ruleSet
+}
+
+// compiles the patterns of a rule set, returning the first invalid pattern's error
+func (s *SecretRuleSet) compile() error {
+	for _, rule := range s.Rules {
+		matcher, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return err
+		}
+		rule.matcher = matcher
+	}
+	return nil
+}
+
+// Loads the secret rule set from a JSON file, keeping the default rule set if
+// no path is given.
+//
+// Params:
+// 	path (string) : path to the rule set file
+// Returns:
+// 	(error) : an error if the file cannot be read or contains an invalid pattern
+func loadSecretRules(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	ruleSet := &SecretRuleSet{}
+	if err := json.Unmarshal(data, ruleSet); err != nil {
+		return err
+	} else if err := ruleSet.compile(); err != nil {
+		return err
+	}
+	secretRules = ruleSet
+	log.Printf("[INFO] Loaded %d secret scanning rules from %s", len(ruleSet.Rules), path)
+	return nil
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function to get the private secret report of a submission. Only
+// authors of the submission and editors are allowed to see it
+// GET /submission/{id}/secrets
+func GetSubmissionSecrets(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionSecretsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if resp.Findings, err = getSecretFindings(submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Only authors and editors can view a submission's secret report.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] could not get secret report: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get secret report", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the stored secret findings of a submission, checking that the user is
+// one of its authors or an editor
+func getSecretFindings(submissionID uint, ctx *RequestContext) ([]SecretFinding, error) {
+	findings := []SecretFinding{}
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Authors").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+		if ctx.UserType != USERTYPE_EDITOR && !isUserInList(ctx.ID, submission.Authors) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		}
+		return tx.Where("submission_id = ?", submissionID).Order("path, line").Find(&findings).Error
+	}); err != nil {
+		return nil, err
+	}
+	return findings, nil
+}
+
+// Scans the files of a submission for credentials, applying the journal's
+// secret policy. Files must not have been stored yet.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction the submission is being added in
+// 	files ([]File) : the files to scan
+// Returns:
+// 	([]SecretFinding) : the findings to attach to the submission once its files are stored
+// 	(error) : a *SecretsFoundError if the policy is to block uploads with secrets
+func applySecretPolicy(tx *gorm.DB, files []File) ([]SecretFinding, error) {
+	findings := scanFilesForSecrets(secretRules, files)
+	if len(findings) == 0 {
+		return nil, nil
+	}
+	settings, err := getJournalSettings(tx)
+	if err != nil {
+		return nil, err
+	} else if settings.SecretScanPolicy != SECRET_POLICY_REPORT {
+		return nil, &SecretsFoundError{Findings: findings}
+	}
+	return findings, nil
+}
+
+// Scans a list of files for credentials.
+//
+// Params:
+// 	ruleSet (*SecretRuleSet) : the rules to match file content against
+// 	files ([]File) : the files to scan, with content set as base64
+// Returns:
+// 	([]SecretFinding) : a finding per line and rule matched, with path set
+func scanFilesForSecrets(ruleSet *SecretRuleSet, files []File) []SecretFinding {
+	findings := []SecretFinding{}
+	for _, file := range files {
+		if isIgnoredPath(ruleSet, file.Path) {
+			continue
+		}
+		findings = append(findings, scanContentForSecrets(ruleSet, file.Path, decodeFileContent(file.Base64Value))...)
+	}
+	return findings
+}
+
+// checks whether a file path matches one of a rule set's ignored paths
+func isIgnoredPath(ruleSet *SecretRuleSet, path string) bool {
+	for _, pattern := range ruleSet.IgnorePaths {
+		if match, _ := filepath.Match(pattern, filepath.Base(path)); match {
+			return true
+		}
+	}
+	return false
+}
+
+// decodes a file's base64 content, falling back on the raw value if it is not valid base64
+func decodeFileContent(base64Value string) []byte {
+	if content, err := base64.StdEncoding.DecodeString(base64Value); err == nil {
+		return content
+	}
+	return []byte(base64Value)
+}
+
+// Scans a single file's content line by line. Binary content is skipped.
+func scanContentForSecrets(ruleSet *SecretRuleSet, path string, content []byte) []SecretFinding {
+	findings := []SecretFinding{}
+	if bytes.IndexByte(content, 0) != -1 {
+		return findings
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(make([]byte, 64*1024), len(content)+1)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := scanner.Text()
+		matched := false
+		for _, rule := range ruleSet.Rules {
+			if match := rule.matcher.FindString(line); match != "" {
+				findings = append(findings, SecretFinding{Path: path, Line: lineNumber, Rule: rule.Name, Preview: redactSecret(match)})
+				matched = true
+			}
+		}
+		// high entropy strings are only reported on lines no other rule explains
+		if ruleSet.EntropyEnabled && !matched {
+			for _, token := range entropyTokenMatcher.FindAllString(line, -1) {
+				if len(token) >= ruleSet.EntropyMinLength && shannonEntropy(token) >= ruleSet.EntropyThreshold {
+					findings = append(findings, SecretFinding{Path: path, Line: lineNumber, Rule: RULE_ENTROPY, Preview: redactSecret(token)})
+					break
+				}
+			}
+		}
+	}
+	return findings
+}
+
+// computes the Shannon entropy of a string in bits per character
+func shannonEntropy(s string) float64 {
+	frequencies := make(map[rune]float64)
+	for _, c := range s {
+		frequencies[c]++
+	}
+	entropy := 0.0
+	length := float64(len(s))
+	for _, count := range frequencies {
+		p := count / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// keeps the first few characters of a matched secret so it can be recognised
+func redactSecret(secret string) string {
+	secret = strings.TrimSpace(secret)
+	if len(secret) <= PREVIEW_LENGTH {
+		return strings.Repeat("*", len(secret))
+	}
+	return secret[:PREVIEW_LENGTH] + strings.Repeat("*", 8)
+}
@@ -0,0 +1,228 @@
+// =========================================================================
+// settings.go
+// Created: October 19, 2026
+//
+// This file takes care of journal-wide settings, which control the policies
+// applied to submissions (i.e. what happens when secrets are uploaded)
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	SUBROUTE_SETTINGS = "/settings"
+
+	JOURNAL_SETTINGS_ID = 1 // settings are stored as a single row in the db
+
+	// policies for submissions containing credentials
+	SECRET_POLICY_BLOCK  = "block"  // reject the upload
+	SECRET_POLICY_REPORT = "report" // accept the upload and attach a private report
+
+	// review modes, deciding which identities are hidden during review
+	REVIEW_MODE_OPEN         = "open"         // nothing is hidden
+	REVIEW_MODE_SINGLE_BLIND = "single_blind" // reviewers are hidden from authors
+	REVIEW_MODE_DOUBLE_BLIND = "double_blind" // reviewers and authors are hidden from each other
+)
+
+// Describe mux routing for journal settings endpoints.
+func getSettingsSubRoutes(r *mux.Router) {
+	settings := r.PathPrefix(SUBROUTE_SETTINGS).Subrouter()
+	settings.Use(jwtMiddleware)
+
+	// Settings routes:
+	// + GET /settings - Get the journal's settings.
+	// + POST /settings/edit - Edit the journal's settings.
+	// + GET /settings/policies - Get the acceptance policies set for tags (in policies.go).
+	// + POST /settings/policies/set - Set the acceptance policy of a tag (in policies.go).
+	// + POST /settings/policies/delete - Make a tag use the journal's acceptance policy again (in policies.go).
+	// + GET /settings/templates - Get the decision letter templates (in letters.go).
+	// + POST /settings/templates/create - Create a decision letter template (in letters.go).
+	// + POST /settings/templates/{id}/edit - Edit a decision letter template (in letters.go).
+	// + POST /settings/templates/{id}/delete - Delete a decision letter template (in letters.go).
+	settings.HandleFunc("", GetJournalSettings).Methods(http.MethodGet)
+	settings.HandleFunc(ENDPOINT_EDIT, PostEditJournalSettings).Methods(http.MethodPost, http.MethodOptions)
+	settings.HandleFunc(ENDPOINT_POLICIES, GetAcceptancePolicies).Methods(http.MethodGet)
+	settings.HandleFunc(ENDPOINT_POLICIES+ENDPOINT_SET_POLICY, PostSetAcceptancePolicy).Methods(http.MethodPost, http.MethodOptions)
+	settings.HandleFunc(ENDPOINT_POLICIES+ENDPOINT_DELETE, PostDeleteAcceptancePolicy).Methods(http.MethodPost, http.MethodOptions)
+	settings.HandleFunc(ENDPOINT_TEMPLATES, GetLetterTemplates).Methods(http.MethodGet)
+	settings.HandleFunc(ENDPOINT_TEMPLATES+ENDPOINT_CREATE_TEMPLATE, PostCreateLetterTemplate).Methods(http.MethodPost, http.MethodOptions)
+	settings.HandleFunc(ENDPOINT_TEMPLATES+"/{id}"+ENDPOINT_EDIT, PostEditLetterTemplate).Methods(http.MethodPost, http.MethodOptions)
+	settings.HandleFunc(ENDPOINT_TEMPLATES+"/{id}"+ENDPOINT_DELETE, PostDeleteLetterTemplate).Methods(http.MethodPost, http.MethodOptions)
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to view journal settings
+// GET /settings
+func GetJournalSettings(w http.ResponseWriter, r *http.Request) {
+	resp := &GetJournalSettingsResponse{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to view journal settings.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if settings, err := getJournalSettings(gormDb); err != nil {
+		log.Printf("[ERROR] could not get journal settings: %v\n", err)
+		resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get journal settings", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+
+	} else {
+		resp.Settings = settings
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for editors to change journal settings. Only the fields
+// set in the request body are changed
+// POST /settings/edit
+func PostEditJournalSettings(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Journal settings updated successfully", Error: false}
+	reqBody := &EditJournalSettingsBody{}
+
+	if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp = &StandardResponse{Message: "The client must have editor permissions to edit journal settings.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerEditJournalSettings(reqBody); err != nil {
+		log.Printf("[ERROR] could not edit journal settings: %v\n", err)
+		resp = &StandardResponse{Message: "Internal Server Error - could not edit journal settings", Error: true}
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which applies the set fields of an edit settings body to the
+// journal's settings, creating the settings row if it does not exist yet
+func ControllerEditJournalSettings(r *EditJournalSettingsBody) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		// the row is created with the defaults before being edited, as creating it with the
+		// edited settings would replace their zero values with the column defaults
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(defaultJournalSettings()).Error; err != nil {
+			return err
+		}
+		settings, err := getJournalSettings(tx)
+		if err != nil {
+			return err
+		}
+		if r.SecretScanPolicy != "" {
+			settings.SecretScanPolicy = r.SecretScanPolicy
+		}
+		if r.BinaryFilePolicy != "" {
+			settings.BinaryFilePolicy = r.BinaryFilePolicy
+		}
+		if r.ExecutableFilePolicy != "" {
+			settings.ExecutableFilePolicy = r.ExecutableFilePolicy
+		}
+		if r.ArchiveFilePolicy != "" {
+			settings.ArchiveFilePolicy = r.ArchiveFilePolicy
+		}
+		if r.OversizedFilePolicy != "" {
+			settings.OversizedFilePolicy = r.OversizedFilePolicy
+		}
+		if r.MaxFileSize != nil {
+			settings.MaxFileSize = *r.MaxFileSize
+		}
+		if r.ReviewMode != "" {
+			settings.ReviewMode = r.ReviewMode
+		}
+		if r.RedactAuthorNames != nil {
+			settings.RedactAuthorNames = *r.RedactAuthorNames
+		}
+		if r.CoauthorConflictYears != nil {
+			settings.CoauthorConflictYears = *r.CoauthorConflictYears
+		}
+		if r.ReviewPeriodDays != 0 {
+			settings.ReviewPeriodDays = r.ReviewPeriodDays
+		}
+		if r.InvitationExpiryDays != 0 {
+			settings.InvitationExpiryDays = r.InvitationExpiryDays
+		}
+		if r.ReminderDays != nil {
+			settings.ReminderDays = *r.ReminderDays
+		}
+		if r.AcceptancePolicy != "" {
+			settings.AcceptancePolicy = r.AcceptancePolicy
+		}
+		if r.MinApprovals != 0 {
+			settings.MinApprovals = r.MinApprovals
+		}
+		if r.PublishReviews != nil {
+			settings.PublishReviews = *r.PublishReviews
+		}
+		return tx.Save(settings).Error
+	})
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the journal's settings, falling back on the defaults if no settings
+// have been saved yet
+//
+// Params:
+// 	tx (*gorm.DB) : the db instance to query on (may be a transaction)
+// Returns:
+// 	(*JournalSettings) : the journal's current settings
+// 	(error) : an error if one occurs
+func getJournalSettings(tx *gorm.DB) (*JournalSettings, error) {
+	settings := defaultJournalSettings()
+	if err := tx.Limit(1).Find(settings, JOURNAL_SETTINGS_ID).Error; err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// gets the settings used by the journal before any editor changes them
+func defaultJournalSettings() *JournalSettings {
+	return &JournalSettings{
+		ID:                   JOURNAL_SETTINGS_ID,
+		SecretScanPolicy:     SECRET_POLICY_BLOCK,
+		BinaryFilePolicy:     FILE_POLICY_ATTACHMENT,
+		ExecutableFilePolicy: FILE_POLICY_REJECT,
+		ArchiveFilePolicy:    FILE_POLICY_ATTACHMENT,
+		OversizedFilePolicy:  FILE_POLICY_REJECT,
+		MaxFileSize:          10 * 1024 * 1024,
+		ReviewMode:           REVIEW_MODE_OPEN,
+
+		CoauthorConflictYears: 3,
+		ReviewPeriodDays:      21,
+		InvitationExpiryDays:  7,
+		ReminderDays:          3,
+		AcceptancePolicy:      ACCEPTANCE_UNANIMOUS,
+		MinApprovals:          1,
+	}
+}
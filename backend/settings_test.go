@@ -0,0 +1,92 @@
+// =====================================
+// settings_test.go
+// Created: October 19, 2026
+//
+// test file for settings.go
+// =====================================
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Router Function Tests
+// ------------
+
+func TestPostEditJournalSettings(t *testing.T) {
+	testInit()
+	defer testEnd()
+
+	router := mux.NewRouter()
+	router.HandleFunc(SUBROUTE_SETTINGS+ENDPOINT_EDIT, PostEditJournalSettings)
+
+	// does the work of sending a test request and returns the status code
+	testEditSettings := func(reqStruct interface{}, ctxStruct *RequestContext) int {
+		reqBody, err := json.Marshal(reqStruct)
+		if !assert.NoError(t, err, "Error while marshalling edit settings body!") {
+			return -1
+		}
+		req := httptest.NewRequest(http.MethodPost, SUBROUTE_SETTINGS+ENDPOINT_EDIT, bytes.NewBuffer(reqBody))
+		w := httptest.NewRecorder()
+		ctx := context.WithValue(req.Context(), "data", ctxStruct)
+		router.ServeHTTP(w, req.WithContext(ctx))
+		return w.Result().StatusCode
+	}
+	editorCtx := &RequestContext{ID: "editor", UserType: USERTYPE_EDITOR}
+
+	t.Run("Default Settings", func(t *testing.T) {
+		settings, err := getJournalSettings(gormDb)
+		if assert.NoError(t, err, "could not get journal settings") {
+			assert.Equal(t, SECRET_POLICY_BLOCK, settings.SecretScanPolicy, "wrong default secret policy")
+		}
+	})
+
+	t.Run("Clear Limits On First Edit", func(t *testing.T) {
+		zeroSize, zeroDays := int64(0), 0
+		status := testEditSettings(&EditJournalSettingsBody{MaxFileSize: &zeroSize,
+			CoauthorConflictYears: &zeroDays, ReminderDays: &zeroDays}, editorCtx)
+		if !assert.Equal(t, http.StatusOK, status, "request did not succeed!") {
+			return
+		}
+		settings, err := getJournalSettings(gormDb)
+		switch {
+		case !assert.NoError(t, err, "could not get journal settings"),
+			!assert.Zero(t, settings.MaxFileSize, "file size limit not cleared"),
+			!assert.Zero(t, settings.CoauthorConflictYears, "co-author conflict period not cleared"),
+			!assert.Zero(t, settings.ReminderDays, "reminders not disabled"):
+			return
+		}
+	})
+
+	t.Run("Edit Secret Policy", func(t *testing.T) {
+		status := testEditSettings(&EditJournalSettingsBody{SecretScanPolicy: SECRET_POLICY_REPORT}, editorCtx)
+		if !assert.Equal(t, http.StatusOK, status, "request did not succeed!") {
+			return
+		}
+		settings, err := getJournalSettings(gormDb)
+		if assert.NoError(t, err, "could not get journal settings") {
+			assert.Equal(t, SECRET_POLICY_REPORT, settings.SecretScanPolicy, "secret policy not updated")
+		}
+	})
+
+	t.Run("Invalid Secret Policy", func(t *testing.T) {
+		status := testEditSettings(&EditJournalSettingsBody{SecretScanPolicy: "ignore"}, editorCtx)
+		assert.Equal(t, http.StatusBadRequest, status, "invalid policy accepted")
+	})
+
+	t.Run("Not Editor", func(t *testing.T) {
+		status := testEditSettings(&EditJournalSettingsBody{SecretScanPolicy: SECRET_POLICY_BLOCK},
+			&RequestContext{ID: "publisher", UserType: USERTYPE_PUBLISHER})
+		assert.Equal(t, http.StatusUnauthorized, status, "non-editor could edit settings")
+	})
+}
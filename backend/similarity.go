@@ -0,0 +1,417 @@
+// =========================================================================
+// similarity.go
+// Created: October 19, 2026
+//
+// This file takes care of code similarity detection between submissions.
+// Files are fingerprinted on upload by winnowing hashed k-grams of their
+// normalised token stream, and fingerprints are compared across submissions
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"log"
+	"math"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_SIMILAR = "/similar"
+
+	FINGERPRINT_K_GRAM = 12   // number of tokens hashed together, matches shorter than this are ignored
+	FINGERPRINT_WINDOW = 8    // winnowing window, matches of K_GRAM+WINDOW-1 tokens are always found
+	SIMILAR_LIMIT      = 10   // default number of similar submissions returned
+	FINGERPRINT_BATCH  = 1000 // maximum number of fingerprints per query
+)
+
+// comments and literals of each family of languages. Literals are matched so that
+// comment markers in them are kept, and comments are the first group
+var (
+	// C, C++, Java, Go, Rust...: single quotes only hold a character, so Rust lifetimes are left alone
+	cCommentMatcher = regexp.MustCompile(`(?s)"(?:\\.|[^"\\\n])*"|` + "`[^`]*`" +
+		`|'(?:\\(?:'|[^'\n]{1,10})|[^'\\\n])'|(/\*.*?\*/|//[^\n]*)`)
+	// JavaScript, TypeScript, PHP...: single quoted and template strings
+	scriptCommentMatcher = regexp.MustCompile(`(?s)"(?:\\.|[^"\\\n])*"|'(?:\\.|[^'\\\n])*'|` +
+		"`(?:\\\\.|[^`\\\\])*`" + `|(/\*.*?\*/|//[^\n]*)`)
+	// Python, Ruby, shell scripts...: hash comments and triple quoted strings
+	hashCommentMatcher = regexp.MustCompile(`(?s)""".*?"""|'''.*?'''|"(?:\\.|[^"\\\n])*"|'(?:\\.|[^'\\\n])*'|(#[^\n]*)`)
+)
+
+// comment syntax of each file extension, files with other extensions keep their comments
+var commentMatchers = map[string]*regexp.Regexp{
+	".c": cCommentMatcher, ".h": cCommentMatcher, ".cc": cCommentMatcher, ".cpp": cCommentMatcher,
+	".cxx": cCommentMatcher, ".hpp": cCommentMatcher, ".cs": cCommentMatcher, ".java": cCommentMatcher,
+	".kt": cCommentMatcher, ".scala": cCommentMatcher, ".go": cCommentMatcher, ".rs": cCommentMatcher,
+	".swift": cCommentMatcher, ".dart": cCommentMatcher,
+	".js": scriptCommentMatcher, ".jsx": scriptCommentMatcher, ".mjs": scriptCommentMatcher,
+	".ts": scriptCommentMatcher, ".tsx": scriptCommentMatcher, ".php": scriptCommentMatcher,
+	".py": hashCommentMatcher, ".rb": hashCommentMatcher, ".sh": hashCommentMatcher, ".bash": hashCommentMatcher,
+	".pl": hashCommentMatcher, ".r": hashCommentMatcher, ".yml": hashCommentMatcher, ".yaml": hashCommentMatcher,
+	".toml": hashCommentMatcher,
+}
+
+// identifiers, numbers, double quoted strings and single punctuation characters
+var tokenMatcher = regexp.MustCompile(`[A-Za-z_][A-Za-z0-9_]*|[0-9][A-Za-z0-9_.]*|"(?:\\.|[^"\\\n])*"|\S`)
+
+// keywords common to most languages, kept as is so that renaming identifiers
+// does not change the structure of the token stream
+var keywords = map[string]struct{}{
+	"if": {}, "else": {}, "elif": {}, "for": {}, "while": {}, "do": {}, "switch": {}, "case": {},
+	"break": {}, "continue": {}, "return": {}, "func": {}, "function": {}, "def": {}, "class": {},
+	"struct": {}, "interface": {}, "import": {}, "from": {}, "package": {}, "try": {}, "catch": {},
+	"except": {}, "finally": {}, "throw": {}, "raise": {}, "new": {}, "var": {}, "let": {},
+	"const": {}, "static": {}, "public": {}, "private": {}, "protected": {}, "void": {}, "int": {},
+	"float": {}, "double": {}, "char": {}, "bool": {}, "string": {}, "true": {}, "false": {},
+	"null": {}, "nil": {}, "None": {}, "and": {}, "or": {}, "not": {}, "in": {}, "range": {},
+	"lambda": {}, "yield": {}, "go": {}, "defer": {}, "type": {}, "map": {}, "fn": {}, "impl": {},
+}
+
+// ------
+// Router Functions
+// ------
+
+// router function for editors to get the submissions most similar to a given one
+// GET /submission/{id}/similar
+func GetSimilarSubmissions(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSimilarSubmissionsResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	limit, limitErr := SIMILAR_LIMIT, error(nil)
+	if limitParam := r.URL.Query().Get("limit"); limitParam != "" {
+		limit, limitErr = strconv.Atoi(limitParam)
+	}
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if ctx.UserType != USERTYPE_EDITOR {
+		resp.StandardResponse = StandardResponse{Message: "The client must have editor permissions to compare submissions.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if limitErr != nil || limit < 1 {
+		err = &BadQueryParameterError{ParamName: "limit", Value: r.URL.Query().Get("limit")}
+		resp.StandardResponse = StandardResponse{Message: fmt.Sprintf("Bad Request - %s", err.Error()), Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.Submissions, err = getSimilarSubmissions(submissionID, limit); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not compare submissions: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not compare submissions", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Gets the submissions sharing the most fingerprints with a given submission.
+//
+// Params:
+// 	submissionID (uint) : the submission to compare against all others
+// 	limit (int) : the maximum number of similar submissions returned
+// Returns:
+// 	([]SimilarSubmission) : similar submissions ordered by decreasing overlap
+// 	(error) : an error if one occurs
+func getSimilarSubmissions(submissionID uint, limit int) ([]SimilarSubmission, error) {
+	similar := []SimilarSubmission{}
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Files").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+
+		// groups the submission's fingerprints by file
+		fingerprints := []Fingerprint{}
+		if err := tx.Where("submission_id = ?", submissionID).Find(&fingerprints).Error; err != nil {
+			return err
+		}
+		fileHashes := make(map[uint]map[int64]struct{})
+		allHashes := make(map[int64]struct{})
+		for _, fingerprint := range fingerprints {
+			if fileHashes[fingerprint.FileID] == nil {
+				fileHashes[fingerprint.FileID] = make(map[int64]struct{})
+			}
+			fileHashes[fingerprint.FileID][fingerprint.Hash] = struct{}{}
+			allHashes[fingerprint.Hash] = struct{}{}
+		}
+		if len(allHashes) == 0 {
+			return nil
+		}
+
+		// finds matching fingerprints in other submissions, in batches to keep queries small
+		hashList := make([]int64, 0, len(allHashes))
+		for hash := range allHashes {
+			hashList = append(hashList, hash)
+		}
+		matches := []Fingerprint{}
+		for start := 0; start < len(hashList); start += FINGERPRINT_BATCH {
+			end := start + FINGERPRINT_BATCH
+			if end > len(hashList) {
+				end = len(hashList)
+			}
+			batch := []Fingerprint{}
+			if err := tx.Where("hash IN ? AND submission_id <> ?", hashList[start:end], submissionID).
+				Find(&batch).Error; err != nil {
+				return err
+			}
+			matches = append(matches, batch...)
+		}
+		similar = compareFingerprints(fileHashes, allHashes, matches)
+		if len(similar) > limit {
+			similar = similar[:limit]
+		}
+
+		// fills in submission names and file paths for display
+		paths := make(map[uint]string)
+		for _, file := range submission.Files {
+			paths[file.ID] = file.Path
+		}
+		otherIDs := make([]uint, len(similar))
+		for i := range similar {
+			otherIDs[i] = similar[i].SubmissionID
+		}
+		others := []Submission{}
+		if len(otherIDs) > 0 {
+			if err := tx.Preload("Files").Select("id, name").Where("id IN ?", otherIDs).Find(&others).Error; err != nil {
+				return err
+			}
+		}
+		names := make(map[uint]string)
+		for _, other := range others {
+			names[other.ID] = other.Name
+			for _, file := range other.Files {
+				paths[file.ID] = file.Path
+			}
+		}
+		for i := range similar {
+			similar[i].Name = names[similar[i].SubmissionID]
+			for j := range similar[i].MatchingFiles {
+				similar[i].MatchingFiles[j].Path = paths[similar[i].MatchingFiles[j].FileID]
+				similar[i].MatchingFiles[j].OtherPath = paths[similar[i].MatchingFiles[j].OtherFileID]
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return similar, nil
+}
+
+// Computes overlap percentages between a submission's fingerprints and the
+// matching fingerprints of other submissions.
+//
+// Params:
+// 	fileHashes (map[uint]map[int64]struct{}) : the submission's fingerprints grouped by file ID
+// 	allHashes (map[int64]struct{}) : the submission's distinct fingerprints
+// 	matches ([]Fingerprint) : fingerprints of other submissions sharing a hash with the submission
+// Returns:
+// 	([]SimilarSubmission) : other submissions ordered by decreasing overlap, without names or paths
+func compareFingerprints(fileHashes map[uint]map[int64]struct{}, allHashes map[int64]struct{}, matches []Fingerprint) []SimilarSubmission {
+	// shared distinct hashes per other submission, and per (file, other file) pair
+	type filePair struct{ fileID, otherFileID uint }
+	submissionShared := make(map[uint]map[int64]struct{})
+	pairShared := make(map[uint]map[filePair]map[int64]struct{})
+	for _, match := range matches {
+		if submissionShared[match.SubmissionID] == nil {
+			submissionShared[match.SubmissionID] = make(map[int64]struct{})
+			pairShared[match.SubmissionID] = make(map[filePair]map[int64]struct{})
+		}
+		submissionShared[match.SubmissionID][match.Hash] = struct{}{}
+		for fileID, hashes := range fileHashes {
+			if _, ok := hashes[match.Hash]; !ok {
+				continue
+			}
+			pair := filePair{fileID: fileID, otherFileID: match.FileID}
+			if pairShared[match.SubmissionID][pair] == nil {
+				pairShared[match.SubmissionID][pair] = make(map[int64]struct{})
+			}
+			pairShared[match.SubmissionID][pair][match.Hash] = struct{}{}
+		}
+	}
+
+	similar := []SimilarSubmission{}
+	for otherID, shared := range submissionShared {
+		result := SimilarSubmission{
+			SubmissionID:  otherID,
+			Overlap:       percentage(len(shared), len(allHashes)),
+			MatchingFiles: []MatchingFilePair{},
+		}
+		for pair, hashes := range pairShared[otherID] {
+			result.MatchingFiles = append(result.MatchingFiles, MatchingFilePair{
+				FileID: pair.fileID, OtherFileID: pair.otherFileID,
+				Overlap: percentage(len(hashes), len(fileHashes[pair.fileID])),
+			})
+		}
+		sort.Slice(result.MatchingFiles, func(i, j int) bool {
+			if result.MatchingFiles[i].Overlap != result.MatchingFiles[j].Overlap {
+				return result.MatchingFiles[i].Overlap > result.MatchingFiles[j].Overlap
+			}
+			return result.MatchingFiles[i].FileID < result.MatchingFiles[j].FileID
+		})
+		similar = append(similar, result)
+	}
+	sort.Slice(similar, func(i, j int) bool {
+		if similar[i].Overlap != similar[j].Overlap {
+			return similar[i].Overlap > similar[j].Overlap
+		}
+		return similar[i].SubmissionID < similar[j].SubmissionID
+	})
+	return similar
+}
+
+// gets a percentage rounded to two decimal places
+func percentage(part int, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
+
+// Fingerprints the text files of a submission and stores the fingerprints.
+// Files must already have been added to the db.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction the files are being added in
+// 	submissionID (uint) : the submission the files belong to
+// 	files ([]File) : the submission's files, with content set as base64
+// Returns:
+// 	(error) : an error if one occurs
+func addFingerprints(tx *gorm.DB, submissionID uint, files []File) error {
+	fingerprints := []Fingerprint{}
+	for _, file := range files {
+		if file.Classification != FILE_CLASS_TEXT {
+			continue
+		}
+		for _, hash := range fingerprintContent(file.Path, string(decodeFileContent(file.Base64Value))) {
+			fingerprints = append(fingerprints, Fingerprint{SubmissionID: submissionID, FileID: file.ID, Hash: hash})
+		}
+	}
+	if len(fingerprints) == 0 {
+		return nil
+	}
+	return tx.CreateInBatches(&fingerprints, FINGERPRINT_BATCH).Error
+}
+
+// Computes the winnowed fingerprints of a file's content.
+//
+// Params:
+// 	path (string) : the file's path, its extension gives the syntax of comments
+// 	content (string) : the file's text content
+// Returns:
+// 	([]int64) : the distinct selected k-gram hashes
+func fingerprintContent(path string, content string) []int64 {
+	tokens := normaliseTokens(path, content)
+	if len(tokens) < FINGERPRINT_K_GRAM {
+		return []int64{}
+	}
+	hashes := make([]int64, len(tokens)-FINGERPRINT_K_GRAM+1)
+	for i := range hashes {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(strings.Join(tokens[i:i+FINGERPRINT_K_GRAM], "\x00")))
+		hashes[i] = int64(hasher.Sum64() & math.MaxInt64) // kept positive to be stored as a signed integer
+	}
+	return winnow(hashes, FINGERPRINT_WINDOW)
+}
+
+// Splits content into tokens with comments removed, identifiers replaced by a
+// placeholder and literals replaced by their kind.
+func normaliseTokens(path string, content string) []string {
+	content = stripComments(path, content)
+	tokens := tokenMatcher.FindAllString(content, -1)
+	for i, token := range tokens {
+		switch c := token[0]; {
+		case c == '"':
+			tokens[i] = "S"
+		case c >= '0' && c <= '9':
+			tokens[i] = "N"
+		case c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z'):
+			if _, ok := keywords[token]; !ok {
+				tokens[i] = "V"
+			}
+		}
+	}
+	return tokens
+}
+
+// replaces the comments in a file's content by spaces and its string and
+// character literals by empty strings, using the comment syntax of the file's
+// language. Files in unknown languages are left as they are
+func stripComments(path string, content string) string {
+	matcher, ok := commentMatchers[strings.ToLower(filepath.Ext(path))]
+	if !ok {
+		return content
+	}
+	var stripped strings.Builder
+	last := 0
+	for _, match := range matcher.FindAllStringSubmatchIndex(content, -1) {
+		stripped.WriteString(content[last:match[0]])
+		if match[2] < 0 {
+			stripped.WriteString(`""`) // a literal, whose content is not compared
+		} else {
+			stripped.WriteString(" ")
+		}
+		last = match[1]
+	}
+	stripped.WriteString(content[last:])
+	return stripped.String()
+}
+
+// Selects the rightmost minimum hash of every window of hashes, recording
+// each selected position once.
+func winnow(hashes []int64, window int) []int64 {
+	selected := []int64{}
+	if len(hashes) == 0 {
+		return selected
+	} else if len(hashes) < window {
+		window = len(hashes)
+	}
+	seen := make(map[int64]struct{})
+	lastIndex := -1
+	for start := 0; start+window <= len(hashes); start++ {
+		minIndex := start
+		for i := start; i < start+window; i++ {
+			if hashes[i] <= hashes[minIndex] {
+				minIndex = i
+			}
+		}
+		if minIndex != lastIndex {
+			lastIndex = minIndex
+			if _, ok := seen[hashes[minIndex]]; !ok {
+				seen[hashes[minIndex]] = struct{}{}
+				selected = append(selected, hashes[minIndex])
+			}
+		}
+	}
+	return selected
+}
@@ -0,0 +1,180 @@
+// =====================================
+// similarity_test.go
+// Created: October 19, 2026
+//
+// test file for similarity.go
+// =====================================
+
+package main
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_SIMILAR_CODE = `
+def fibonacci(n):
+    # computes the nth fibonacci number
+    a, b = 0, 1
+    for i in range(n):
+        a, b = b, a + b
+    return a
+
+def main():
+    for i in range(10):
+        print(fibonacci(i))
+`
+	TEST_RENAMED_CODE = `
+def fib(count):
+    first, second = 0, 1
+    for index in range(count):
+        first, second = second, first + second
+    return first
+
+def main():
+    for i in range(10):
+        print(fib(i))
+`
+	TEST_UNRELATED_CODE = `
+class Stack:
+    def __init__(self):
+        self.items = []
+
+    def push(self, item):
+        self.items.append(item)
+
+    def pop(self):
+        if not self.items:
+            raise IndexError("empty stack")
+        return self.items.pop()
+`
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that fingerprints ignore comments, whitespace and identifier names
+func TestFingerprintContent(t *testing.T) {
+	original := fingerprintContent("main.py", TEST_SIMILAR_CODE)
+	if !assert.NotEmpty(t, original, "no fingerprints computed") {
+		return
+	}
+	assert.ElementsMatch(t, original, fingerprintContent("main.py", TEST_RENAMED_CODE), "renamed code has different fingerprints")
+	assert.Empty(t, fingerprintContent("main.py", "x = 1"), "content shorter than a k-gram fingerprinted")
+
+	shared := 0
+	unrelated := fingerprintContent("main.py", TEST_UNRELATED_CODE)
+	for _, hash := range unrelated {
+		for _, other := range original {
+			if hash == other {
+				shared++
+			}
+		}
+	}
+	assert.Less(t, shared, len(unrelated)/2, "unrelated code shares most fingerprints")
+}
+
+// tests that comments are removed in the file's language, and not in literals
+func TestStripComments(t *testing.T) {
+	testCases := []struct {
+		name     string
+		path     string
+		content  string
+		stripped string
+	}{
+		{"line comment", "main.c", "x = 1; // one\ny = 2;", "x = 1;  \ny = 2;"},
+		{"block comment", "Main.java", "a /* b\nc */ d", "a   d"},
+		{"hash comment", "main.py", "x = 1 # one", "x = 1  "},
+		{"url in string", "main.go", `url := "http://example.com" // site`, `url := ""  `},
+		{"hash in string", "main.py", "colour = '#fff' # white", `colour = ""  `},
+		{"escaped quote in string", "main.js", `s = "a\"//b" + 'c\'//d'`, `s = "" + ""`},
+		{"preprocessor directive", "main.c", "#include <stdio.h>\n#define N 2 // two", "#include <stdio.h>\n#define N 2  "},
+		{"character literals", "main.c", `c = '\''; d = '"'; // quote`, `c = ""; d = "";  `},
+		{"rust lifetime", "main.rs", "fn f<'a>(x: &'a str) {} // it's", "fn f<'a>(x: &'a str) {}  "},
+		{"python docstring", "main.py", "\"\"\"\n# not a comment\n\"\"\"\nx = 1", "\"\"\nx = 1"},
+		{"unknown language", "notes.txt", "# title // kept", "# title // kept"},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			assert.Equal(t, testCase.stripped, stripComments(testCase.path, testCase.content), "wrong content without comments")
+		})
+	}
+}
+
+// tests that winnowing selects a hash from every window
+func TestWinnow(t *testing.T) {
+	hashes := []int64{77, 74, 42, 17, 98, 50, 17, 98, 8, 88, 67, 39, 77, 74, 42, 17, 98}
+	assert.Equal(t, []int64{17, 8, 39}, winnow(hashes, 4), "wrong hashes selected")
+	assert.Equal(t, []int64{3}, winnow([]int64{5, 3}, 4), "short hash list not winnowed")
+	assert.Empty(t, winnow([]int64{}, 4), "empty hash list winnowed")
+}
+
+// tests overlap computation between a submission and others' fingerprints
+func TestCompareFingerprints(t *testing.T) {
+	fileHashes := map[uint]map[int64]struct{}{
+		1: {10: {}, 11: {}, 12: {}, 13: {}},
+		2: {20: {}, 21: {}, 22: {}, 23: {}},
+	}
+	allHashes := map[int64]struct{}{}
+	for _, hashes := range fileHashes {
+		for hash := range hashes {
+			allHashes[hash] = struct{}{}
+		}
+	}
+	matches := []Fingerprint{
+		{SubmissionID: 5, FileID: 50, Hash: 10}, {SubmissionID: 5, FileID: 50, Hash: 11},
+		{SubmissionID: 5, FileID: 51, Hash: 20}, {SubmissionID: 5, FileID: 51, Hash: 21},
+		{SubmissionID: 5, FileID: 51, Hash: 22}, {SubmissionID: 5, FileID: 51, Hash: 23},
+		{SubmissionID: 6, FileID: 60, Hash: 12},
+	}
+	similar := compareFingerprints(fileHashes, allHashes, matches)
+	switch {
+	case !assert.Len(t, similar, 2, "wrong number of similar submissions"),
+		!assert.Equal(t, uint(5), similar[0].SubmissionID, "most similar submission not first"),
+		!assert.Equal(t, 75.0, similar[0].Overlap, "wrong submission overlap"),
+		!assert.Equal(t, 12.5, similar[1].Overlap, "wrong submission overlap"),
+		!assert.Len(t, similar[0].MatchingFiles, 2, "wrong number of matching files"),
+		!assert.Equal(t, MatchingFilePair{FileID: 2, OtherFileID: 51, Overlap: 100}, similar[0].MatchingFiles[0]),
+		!assert.Equal(t, MatchingFilePair{FileID: 1, OtherFileID: 50, Overlap: 50}, similar[0].MatchingFiles[1]):
+		return
+	}
+}
+
+// tests similarity detection across submissions stored in the db
+func TestGetSimilarSubmissions(t *testing.T) {
+	testInit()
+	defer testEnd()
+
+	globalAuthors, _, err := initMockUsers(t)
+	if err != nil {
+		return
+	}
+	addCodeSubmission := func(name string, code string) uint {
+		submissionID, err := addSubmission(&Submission{
+			Name: name, Authors: []GlobalUser{globalAuthors[0]},
+			Files:    []File{{Path: "main.py", Base64Value: base64.StdEncoding.EncodeToString([]byte(code))}},
+			MetaData: &SubmissionData{Abstract: "test"},
+		}, EVENT_CREATED, "")
+		assert.NoError(t, err, "could not add submission")
+		return submissionID
+	}
+	originalID := addCodeSubmission("Original", TEST_SIMILAR_CODE)
+	copyID := addCodeSubmission("Copy", TEST_RENAMED_CODE)
+	addCodeSubmission("Unrelated", TEST_UNRELATED_CODE)
+
+	similar, err := getSimilarSubmissions(originalID, SIMILAR_LIMIT)
+	switch {
+	case !assert.NoError(t, err, "could not get similar submissions"),
+		!assert.NotEmpty(t, similar, "no similar submissions found"),
+		!assert.Equal(t, copyID, similar[0].SubmissionID, "copy not the most similar submission"),
+		!assert.Equal(t, 100.0, similar[0].Overlap, "copy not fully overlapping"),
+		!assert.Equal(t, "main.py", similar[0].MatchingFiles[0].OtherPath, "matching file path not set"):
+		return
+	}
+	_, err = getSimilarSubmissions(originalID+100, SIMILAR_LIMIT)
+	assert.IsType(t, &NoSubmissionError{}, err, "missing submission compared")
+}
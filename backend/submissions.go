@@ -23,6 +23,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gorilla/mux"
@@ -54,23 +55,81 @@ func getSubmissionsSubRoutes(r *mux.Router) {
 	// + /submission/{id} - Get given submission.
 	// + /submission/{id}/download - Downloads a submission as a zip archive
 	// + /submission/{id}/assignreviewers - Assign reviewers to a given submission (in approval.go)
+	// + /submission/{id}/unassignreviewer - unassign a reviewer from a given submission (in reassignment.go)
+	// + /submission/{id}/replacereviewer - replace a reviewer of a given submission (in reassignment.go)
 	// + /submission/{id}/review - upload a review for a submission (in approval.go)
+	// + /submission/{id}/review/edit - edit a review before the submission is decided (in approval.go)
+	// + /submission/{id}/review/withdraw - withdraw a review before the submission is decided (in approval.go)
 	// + /submission/{id}/approve - change submission status to approve/dissaprove (in approval.go)
 	// + /submission/{id}/export/{groupNumber} - export submission to another journal in the supergroup (in journal.go)
+	// + /submission/{id}/secrets - get the private report of secrets found on upload (in scanning.go)
+	// + /submission/{id}/similar - get the submissions most similar to a given one (in similarity.go)
+	// + /submission/{id}/sbom - export the submission's dependencies as an SBOM (in sbom.go)
+	// + /submission/{id}/vulnerabilities - get the submission's vulnerability report (in vulnerabilities.go)
+	// + /submission/{id}/rubric - get the rubric reviews of the submission are scored against (in rubrics.go)
+	// + /submission/{id}/reviewmode - set the submission's blind review mode (in blind.go)
+	// + /submission/{id}/declareconflict - declare a conflict of interest with the submission (in conflicts.go)
+	// + /submission/{id}/conflicts - get the declared and overridden conflicts of interest (in conflicts.go)
+	// + /submission/{id}/invitation/accept - accept an invitation to review the submission (in invitations.go)
+	// + /submission/{id}/invitation/decline - decline an invitation to review the submission (in invitations.go)
+	// + /submission/{id}/invitations - get every review invitation sent for the submission (in invitations.go)
+	// + /submission/{id}/suggested-reviewers - get the reviewers best suited to review the submission (in recommendations.go)
+	// + /submission/{id}/history - get the submission's lifecycle events (in history.go)
+	// + /submission/{id}/letter - render a decision letter template for the submission (in letters.go)
+	// + /submission/{id}/appeal - appeal the rejection of the submission (in appeals.go)
+	// + /submission/{id}/appeal/resolve - uphold the rejection or reopen the submission (in appeals.go)
+	// + /submission/{id}/appeals - get the appeals against the submission's rejections (in appeals.go)
+	// + /submission/{id}/publishreviews - choose whether the reviews are published once accepted (in publication.go)
+	// + /submission/{id}/review/identity - choose whether the reviewer is named on published reviews (in publication.go)
+	// + /submission/{id}/handlingeditor - assign or reassign the editor handling the submission (in handling.go)
+	// + /submission/{id}/decisions - get the accept and reject decisions made on the submission (in policies.go)
+	// + /submission/{id}/status - move a submission through the review workflow (in workflow.go)
+	// + /submission/{id}/resubmit - upload a revised version of a submission (in workflow.go)
 	submission.HandleFunc("/{id}", RouteGetSubmission).Methods(http.MethodGet)
 	submission.HandleFunc("/{id}"+ENDPOINT_DOWNLOAD_SUBMISSION, GetDownloadSubmission).Methods(http.MethodGet)
 	submission.HandleFunc("/{id}"+ENDPOINT_ASSIGN_REVIEWERS, PostAssignReviewers).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_UNASSIGN_REVIEWER, PostUnassignReviewer).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_REPLACE_REVIEWER, PostReplaceReviewer).Methods(http.MethodPost, http.MethodOptions)
 	submission.HandleFunc("/{id}"+ENPOINT_REVIEW, PostUploadReview).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_EDIT_REVIEW, PostEditReview).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_WITHDRAW_REVIEW, PostWithdrawReview).Methods(http.MethodPost, http.MethodOptions)
 	submission.HandleFunc("/{id}"+ENDPOINT_CHANGE_STATUS, PostUpdateSubmissionStatus).Methods(http.MethodPost, http.MethodOptions)
 	submission.HandleFunc("/{id}"+ENDPOINT_EXPORT_SUBMISSION+"/{groupNumber}", PostExportSubmission).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_SECRETS, GetSubmissionSecrets).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_SIMILAR, GetSimilarSubmissions).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_SBOM, GetSubmissionSbom).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_VULNERABILITIES, GetSubmissionVulnerabilities).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_RUBRIC, GetSubmissionRubric).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_REVIEW_MODE, PostChangeReviewMode).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_DECLARE_CONFLICT, PostDeclareConflict).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_CONFLICTS, GetSubmissionConflicts).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_ACCEPT_INVITATION, PostAcceptInvitation).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_DECLINE_INVITATION, PostDeclineInvitation).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_INVITATIONS, GetSubmissionInvitations).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_SUGGESTED_REVIEWERS, GetSuggestedReviewers).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_HISTORY, GetSubmissionHistory).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_LETTER, GetDecisionLetter).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_APPEAL, PostFileAppeal).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_APPEAL+ENDPOINT_RESOLVE_APPEAL, PostResolveAppeal).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_APPEALS, GetAppeals).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_PUBLISH_REVIEWS, PostPublishReviews).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_REVIEW_IDENTITY, PostReviewIdentity).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_HANDLING_EDITOR, PostAssignHandlingEditor).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_DECISIONS, GetSubmissionDecisions).Methods(http.MethodGet)
+	submission.HandleFunc("/{id}"+ENDPOINT_STATUS, PostChangeSubmissionStatus).Methods(http.MethodPost, http.MethodOptions)
+	submission.HandleFunc("/{id}"+ENDPOINT_RESUBMIT, PostResubmitSubmission).Methods(http.MethodPost, http.MethodOptions)
 
 	// Submissions routes:
 	// + /submissions/tags - gets all available tags currently stored in the database
 	// + /submissions/query - queries a list of submissions based upon parameters
 	// + /submissions/create - Create a submissions
+	// + /submissions/overdue - gets the accepted reviews past their deadline (in invitations.go)
+	// + /submissions/assignments - gets the logged in reviewer's invitations and assignments (in invitations.go)
 	submissions.HandleFunc(ENDPOINT_GET_TAGS, GetAvailableTags).Methods(http.MethodGet)
 	submissions.HandleFunc(ENDPOINT_QUERY, GetQuerySubmissions).Methods(http.MethodGet)
 	submissions.HandleFunc(ENDPOINT_UPLOAD_SUBMISSION, PostUploadSubmissionByZip).Methods(http.MethodPost, http.MethodOptions)
+	submissions.HandleFunc(ENDPOINT_OVERDUE_REVIEWS, GetOverdueReviews).Methods(http.MethodGet)
+	submissions.HandleFunc(ENDPOINT_ASSIGNMENTS, GetReviewerAssignments).Methods(http.MethodGet)
 }
 
 // ------
@@ -180,6 +239,8 @@ func ControllerQuerySubmissions(queryParams url.Values, ctx *RequestContext) ([]
 			tx = tx.Where("id IN (?)", gormDb.Table(
 				"reviewers_submission").Select("submission_id").Where("global_user_id IN ?", queryParams["reviewers"]))
 		}
+		// filters submissions by handling editor
+		tx = filterByHandlingEditor(tx, queryParams["handlingEditors"])
 		// RegEx filtering for submission name
 		if len(queryParams["name"]) > 0 {
 			tx = filterBySubmissionName(tx, regexp.QuoteMeta(queryParams["name"][0]))
@@ -192,7 +253,7 @@ func ControllerQuerySubmissions(queryParams url.Values, ctx *RequestContext) ([]
 			}
 			tx = orderSubmissionQuery(tx, orderBy)
 		}
-		// filters by usertype. If usertype is nil, only show approved submissions
+		// filters by usertype. If usertype is nil, only show accepted submissions
 		tx = filterByUserType(tx, ctx)
 
 		// selects fields and gets submissions
@@ -201,6 +262,15 @@ func ControllerQuerySubmissions(queryParams url.Values, ctx *RequestContext) ([]
 		} else if res.RowsAffected == 0 {
 			return &ResultSetEmptyError{}
 		}
+		// author searches do not find the submissions whose authors are hidden from the user
+		if len(queryParams["authors"]) > 0 {
+			var err error
+			if submissions, err = filterBlindAuthoredSubmissions(gormDb, submissions, ctx); err != nil {
+				return err
+			} else if len(submissions) == 0 {
+				return &ResultSetEmptyError{}
+			}
+		}
 		return nil
 	}); err != nil {
 		return nil, err
@@ -235,24 +305,25 @@ func orderSubmissionQuery(tx *gorm.DB, orderBy string) *gorm.DB {
 	return tx
 }
 
-// adds usertype filters to a submission query
+// adds usertype filters to a submission query. Drafts are only shown to their authors
 func filterByUserType(tx *gorm.DB, ctx *RequestContext) *gorm.DB {
 	if ctx == nil {
-		tx = tx.Where("submissions.approved = ?", true)
+		tx = tx.Where("submissions.status = ?", STATUS_ACCEPTED)
 	} else {
 		if ctx.UserType == USERTYPE_PUBLISHER {
-			tx = tx.Where("approved = ? OR id IN (?)", true,
+			tx = tx.Where("status = ? OR id IN (?)", STATUS_ACCEPTED,
 				gormDb.Table("authors_submission").Select("submission_id").Where("global_user_id = ?", ctx.ID))
 		} else if ctx.UserType == USERTYPE_REVIEWER {
-			tx = tx.Where("approved = ? OR id IN (?)", true,
+			tx = tx.Where("status = ? OR (status <> ? AND id IN (?))", STATUS_ACCEPTED, STATUS_DRAFT,
 				gormDb.Table("reviewers_submission").Select("submission_id").Where("global_user_id = ?", ctx.ID))
 		} else if ctx.UserType == USERTYPE_REVIEWER_PUBLISHER {
-			tx = tx.Where("submissions.approved = ? OR id IN (?) OR id IN (?)", true,
+			tx = tx.Where("submissions.status = ? OR (submissions.status <> ? AND id IN (?)) OR id IN (?)", STATUS_ACCEPTED, STATUS_DRAFT,
 				gormDb.Table("reviewers_submission").Select("submission_id").Where("global_user_id = ?", ctx.ID),
 				gormDb.Table("authors_submission").Select("submission_id").Where("global_user_id = ?", ctx.ID))
+		} else if ctx.UserType == USERTYPE_EDITOR {
+			tx = tx.Where("submissions.status <> ?", STATUS_DRAFT)
 		}
 	}
-	// note editors can see all submissions and hence no filter is added for editors
 	return tx
 }
 
@@ -268,7 +339,7 @@ func adaptBodyToSubmission(b *UploadSubmissionBody) *Submission {
 	for _, category := range b.Tags {
 		categories = append(categories, Category{Tag: category})
 	}
-	return &Submission{
+	submission := &Submission{
 		Name: b.Name, License: b.License,
 		Files: b.Files, Categories: categories,
 		Authors: authors, Reviewers: reviewers,
@@ -277,6 +348,10 @@ func adaptBodyToSubmission(b *UploadSubmissionBody) *Submission {
 		},
 		Runnable: b.Runnable,
 	}
+	if b.Draft {
+		submission.Status = STATUS_DRAFT
+	}
+	return submission
 }
 
 // Router function to upload new submissions by a Zip file with the file contents.
@@ -297,7 +372,7 @@ func PostUploadSubmissionByZip(w http.ResponseWriter, r *http.Request) {
 		resp.Message = "The client is unauthorized from making such request - not a publisher."
 		resp.Error = true
 		w.WriteHeader(http.StatusUnauthorized)
-	} else if submissionID, err := ControllerUploadSubmissionByZip(&reqBody); err != nil {
+	} else if submissionID, err := ControllerUploadSubmissionByZip(&reqBody, ctx.ID); err != nil {
 		switch err.(type) {
 		case validator.ValidationErrors:
 			resp.Message = fmt.Sprintf("Bad fields inserted - %v", err.(validator.ValidationErrors).Error())
@@ -308,7 +383,7 @@ func PostUploadSubmissionByZip(w http.ResponseWriter, r *http.Request) {
 		case *BadUserError:
 			resp.Message = fmt.Sprintf("User %s does not exist in the system.", err.(*BadUserError).userID)
 			w.WriteHeader(http.StatusUnauthorized)
-		case *SubmissionNotRunnableError:
+		case *SubmissionNotRunnableError, *SecretsFoundError, *RejectedFileError:
 			resp.Message = err.Error()
 			w.WriteHeader(http.StatusBadRequest)
 		default:
@@ -327,8 +402,9 @@ func PostUploadSubmissionByZip(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// Controller for the UploadSubmissionByZip POST route.
-func ControllerUploadSubmissionByZip(r *UploadSubmissionByZipBody) (uint, error) {
+// Controller for the UploadSubmissionByZip POST route, recording the given
+// user as the submission's creator in its history.
+func ControllerUploadSubmissionByZip(r *UploadSubmissionByZipBody, actorID string) (uint, error) {
 	if r == nil {
 		log.Printf("[WARN] Empty body given - returning.")
 		return 0, errors.New("Submission is empty")
@@ -345,8 +421,9 @@ func ControllerUploadSubmissionByZip(r *UploadSubmissionByZipBody) (uint, error)
 		Authors: r.Authors, Reviewers: r.Reviewers,
 		Abstract: r.Abstract, Tags: r.Tags,
 		Files: files, Runnable: r.Runnable,
+		Draft: r.Draft,
 	})
-	submissionID, err := addSubmission(submission)
+	submissionID, err := addSubmission(submission, EVENT_CREATED, actorID)
 	if err != nil {
 		return 0, err
 	}
@@ -390,35 +467,65 @@ func RouteGetSubmission(w http.ResponseWriter, r *http.Request) {
 		encodable = submission
 	}
 
-	// submission not approved, can only be displayed for editors, authors or reviewers
-	if submission != nil && (submission.Approved == nil || !*submission.Approved) {
+	// submission not accepted, can only be displayed for editors, authors or reviewers
+	if submission != nil && submission.Status != STATUS_ACCEPTED {
 		if ctx, ok := r.Context().Value("data").(*RequestContext); ok && validate.Struct(ctx) != nil {
 			encodable = &StandardResponse{Message: "Error getting request context", Error: true}
 			w.WriteHeader(http.StatusBadRequest)
 		} else if ctx == nil {
 			encodable = &StandardResponse{Message: "Non-user cannot view unapproved submission", Error: true}
 			w.WriteHeader(http.StatusUnauthorized)
-		} else if ctx.UserType != USERTYPE_EDITOR {
-			// if the user is not an editor, check that they are either a reviewer or author for the given submission
-			var allowed = false
-			for _, author := range submission.Authors {
-				if author.ID == ctx.ID {
-					allowed = true
-					break
-				}
-			}
-			if !allowed {
-				for _, reviewer := range submission.Reviewers {
-					if reviewer.ID == ctx.ID {
-						allowed = true
-						break
-					}
-				}
-			}
-			if !allowed {
-				encodable = &StandardResponse{Message: "Not authorized to access the given submission", Error: true}
-				w.WriteHeader(http.StatusUnauthorized)
+		} else if !canViewSubmission(submission, ctx) {
+			// if the user is not an editor, they must be either a reviewer or author for the given submission
+			encodable = &StandardResponse{Message: "Not authorized to access the given submission", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}
+
+	// aggregates the rubric scores of the current version's reviews
+	if encodable == submission {
+		if submission.RubricScores, err = getRubricScores(currentRoundReviews(submission)); err != nil {
+			log.Printf("[ERROR] could not aggregate submission rubric scores: %v", err)
+		}
+	}
+
+	// flags known vulnerabilities in the submission's dependencies for editors,
+	// and only shows them the history of the submission's reviews
+	if ctx, ok := r.Context().Value("data").(*RequestContext); ok && ctx.UserType == USERTYPE_EDITOR && encodable == submission {
+		if submission.VulnerabilityCounts, err = getVulnerabilityCounts(submission.ID, submission.Version); err != nil {
+			log.Printf("[ERROR] could not count submission vulnerabilities: %v", err)
+		}
+	} else if encodable == submission {
+		hideReviewHistory(submission.MetaData)
+	}
+
+	// hides identities the user cannot see under blind review. Users who did
+	// not take part in the review only see published reviews, under the
+	// identities their reviewers chose (see publication.go)
+	if encodable == submission {
+		ctx, _ := r.Context().Value("data").(*RequestContext)
+		if !isReviewParticipant(submission, ctx) {
+			if settings, err := getJournalSettings(gormDb); err != nil {
+				log.Printf("[ERROR] could not get journal settings: %v", err)
+				encodable = StandardResponse{Message: "Internal server error - could not retrieve submission.", Error: true}
+				w.WriteHeader(http.StatusInternalServerError)
+			} else if invited, err := getInvitedReviewers(gormDb, submission.ID); err != nil {
+				log.Printf("[ERROR] could not get invited reviewers: %v", err)
+				encodable = StandardResponse{Message: "Internal server error - could not retrieve submission.", Error: true}
+				w.WriteHeader(http.StatusInternalServerError)
+			} else {
+				blindSubmission(submission, publishReviews(submission, invited, reviewsPublished(submission, settings)))
 			}
+		} else if mode, err := getReviewMode(gormDb, submission); err != nil {
+			log.Printf("[ERROR] could not get submission review mode: %v", err)
+			encodable = StandardResponse{Message: "Internal server error - could not retrieve submission.", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		} else if aliases, err := getSubmissionAliases(gormDb, submission, mode, ctx); err != nil {
+			log.Printf("[ERROR] could not get submission aliases: %v", err)
+			encodable = StandardResponse{Message: "Internal server error - could not retrieve submission.", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		} else {
+			blindSubmission(submission, aliases)
 		}
 	}
 
@@ -441,7 +548,9 @@ func GetDownloadSubmission(w http.ResponseWriter, r *http.Request) {
 	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-	} else if zipContent, err = ControllerDownloadSubmission(uint(submissionID64)); err != nil {
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); ok && validate.Struct(ctx) != nil {
+		w.WriteHeader(http.StatusBadRequest)
+	} else if zipContent, err = ControllerDownloadSubmission(uint(submissionID64), ctx); err != nil {
 		switch err.(type) {
 		case *NoSubmissionError: // The given submission doesn't exist
 			w.WriteHeader(http.StatusNotFound)
@@ -453,16 +562,18 @@ func GetDownloadSubmission(w http.ResponseWriter, r *http.Request) {
 	w.Write(zipContent)
 }
 
-// Controller for the
+// Controller for the download of a submission. Author names are redacted from
+// file headers for reviewers the authors are hidden from if the journal asks for it
 //
 // Params:
 // 	submissionID (uint) : the unique ID of the submission being downloaded
+// 	ctx (*RequestContext) : the logged in user, nil if no user is logged in
 // Returns:
 // 	(string) : a string of the zip file's contents
 // 	(error) : an error if one occurs
-func ControllerDownloadSubmission(submissionID uint) ([]byte, error) {
+func ControllerDownloadSubmission(submissionID uint, ctx *RequestContext) ([]byte, error) {
 	var submission Submission
-	res := gormDb.Limit(1).Find(&submission, submissionID)
+	res := gormDb.Preload("Authors").Preload("Reviewers").Limit(1).Find(&submission, submissionID)
 	if res.Error != nil {
 		return nil, res.Error
 	} else if res.RowsAffected == 0 {
@@ -477,6 +588,18 @@ func ControllerDownloadSubmission(submissionID uint) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
+	if mode, err := getReviewMode(gormDb, &submission); err != nil {
+		return nil, err
+	} else if areAuthorsHidden(&submission, mode, ctx) {
+		settings, err := getJournalSettings(gormDb)
+		if err != nil {
+			return nil, err
+		} else if settings.RedactAuthorNames {
+			if zipContent, err = redactZipAuthorNames(zipContent, submission.Authors); err != nil {
+				return nil, err
+			}
+		}
+	}
 	retVal := make([]byte, base64.StdEncoding.EncodedLen(len(zipContent)))
 	base64.StdEncoding.Encode(retVal, zipContent)
 	return retVal, nil
@@ -492,10 +615,12 @@ func ControllerDownloadSubmission(submissionID uint) ([]byte, error) {
 // Params:
 //	submission (*Submission) : the submission to be added to the db
 // 		(all fields but ID MUST be set)
+//	eventType (string) : the event starting the submission's history (EVENT_CREATED or EVENT_IMPORTED)
+//	actorID (string) : the user adding the submission, empty if no local user did
 // Returns:
 //	(int) : the id of the added submission
 //	(error) : if the operation fails
-func addSubmission(submission *Submission) (uint, error) {
+func addSubmission(submission *Submission, eventType string, actorID string) (uint, error) {
 	// adds the submission to the db, automatically setting submission.ID
 	if submission == nil {
 		return 0, errors.New("Submission is empty.")
@@ -515,6 +640,7 @@ func addSubmission(submission *Submission) (uint, error) {
 			return 0, &SubmissionNotRunnableError{}
 		}
 	}
+	initSubmissionStatus(submission)
 	err := gormDb.Transaction(func(tx *gorm.DB) error {
 		// Database operations
 		categories := submission.Categories
@@ -536,7 +662,7 @@ func addSubmission(submission *Submission) (uint, error) {
 		if err := addMetaData(submission); err != nil {
 			return err
 		}
-		return nil
+		return recordEvent(tx, submission.ID, eventType, actorID, false, map[string]string{"name": submission.Name})
 	})
 	if err != nil {
 		_ = os.RemoveAll(getSubmissionDirectoryPath(*submission))
@@ -553,7 +679,7 @@ func createSubmissionToDb(tx *gorm.DB, categories []Category, submission *Submis
 	if err := addAuthors(tx, submission.Authors, submission.ID); err != nil {
 		return err
 	}
-	if err := addReviewers(tx, submission.Reviewers, submission.ID); err != nil {
+	if err := addReviewers(tx, submission.Reviewers, submission.ID, time.Time{}, ""); err != nil {
 		return err
 	}
 	if err := tx.Model(&submission).Association("Categories").Append(categories); err != nil {
@@ -574,6 +700,19 @@ func addFiles(tx *gorm.DB, s *Submission) error {
 		}
 	}
 
+	for i := range s.Files {
+		s.Files[i].Version = s.Version
+	}
+
+	// Classify files and scan for credentials before anything is persisted
+	if err := applyFilePolicy(tx, s.Files); err != nil {
+		return err
+	}
+	findings, err := applySecretPolicy(tx, s.Files)
+	if err != nil {
+		return err
+	}
+
 	// Add files to the database
 	model := &Submission{}
 	model.ID = s.ID
@@ -581,6 +720,31 @@ func addFiles(tx *gorm.DB, s *Submission) error {
 		return err
 	}
 
+	// Fingerprint the files for similarity detection and parse their dependencies
+	if err := addFingerprints(tx, s.ID, s.Files); err != nil {
+		return err
+	}
+	if err := addDependencies(tx, s.ID, s.Version, s.Files); err != nil {
+		return err
+	} else if err := evaluateVulnerabilities(tx, s.ID, s.Version); err != nil {
+		return err
+	}
+
+	// Attach the private secret report to the submission's files
+	if len(findings) > 0 {
+		fileIDs := make(map[string]uint)
+		for _, file := range s.Files {
+			fileIDs[file.Path] = file.ID
+		}
+		for i := range findings {
+			findings[i].SubmissionID = s.ID
+			findings[i].FileID = fileIDs[findings[i].Path]
+		}
+		if err := tx.Create(&findings).Error; err != nil {
+			return err
+		}
+	}
+
 	// Add files to directory struct.
 	submissionPath := getSubmissionDirectoryPath(*s)
 	for _, fileRef := range s.Files {
@@ -623,10 +787,37 @@ func addAuthors(tx *gorm.DB, authors []GlobalUser, submissionID uint) error {
 	return appendUsers(tx, authors, []int{USERTYPE_PUBLISHER, USERTYPE_REVIEWER_PUBLISHER}, "Authors", submissionID)
 }
 
-// Add array of registered reviewers with appropriate permissions to a submission's authorized reviewers attribute.
+// Add array of registered reviewers with appropriate permissions to a submission's authorized reviewers attribute,
+// inviting the newly assigned ones to review it by the given due date (the journal's review period from now if zero).
+// Assignments are recorded in the submission's history under the given actor (empty if none).
 // Errors on first unregistered reviewer or reviewer with invalid permissions.
-func addReviewers(tx *gorm.DB, reviewers []GlobalUser, submissionID uint) error {
-	return appendUsers(tx, reviewers, []int{USERTYPE_REVIEWER, USERTYPE_REVIEWER_PUBLISHER}, "Reviewers", submissionID)
+func addReviewers(tx *gorm.DB, reviewers []GlobalUser, submissionID uint, dueAt time.Time, actorID string) error {
+	assigned := []string{}
+	if err := tx.Table("reviewers_submission").Where("submission_id = ?", submissionID).
+		Pluck("global_user_id", &assigned).Error; err != nil {
+		return err
+	}
+	if err := appendUsers(tx, reviewers, []int{USERTYPE_REVIEWER, USERTYPE_REVIEWER_PUBLISHER}, "Reviewers", submissionID); err != nil {
+		return err
+	}
+	isAssigned := make(map[string]bool)
+	for _, reviewerID := range assigned {
+		isAssigned[reviewerID] = true
+	}
+	invited := []string{}
+	for _, reviewer := range reviewers {
+		if !isAssigned[reviewer.ID] {
+			isAssigned[reviewer.ID] = true
+			invited = append(invited, reviewer.ID)
+		}
+	}
+	for _, reviewerID := range invited {
+		if err := recordEvent(tx, submissionID, EVENT_REVIEWER_ASSIGNED, actorID, false,
+			map[string]string{"reviewer": reviewerID}); err != nil {
+			return err
+		}
+	}
+	return inviteReviewers(tx, invited, submissionID, dueAt)
 }
 
 // Append users to submission at given association, with given priviledge restrictions.
@@ -658,6 +849,31 @@ func appendUsers(tx *gorm.DB, users []GlobalUser, priviledges []int, association
 	return nil
 }
 
+// checks whether a user can view a given submission. Accepted submissions are
+// public, drafts can only be viewed by their authors and others by editors and
+// the submission's authors and reviewers. ctx is nil if no user is logged in
+func canViewSubmission(submission *Submission, ctx *RequestContext) bool {
+	if submission.Status == STATUS_ACCEPTED {
+		return true
+	} else if ctx == nil {
+		return false
+	} else if submission.Status == STATUS_DRAFT {
+		return isUserInList(ctx.ID, submission.Authors)
+	}
+	return ctx.UserType == USERTYPE_EDITOR || isUserInList(ctx.ID, submission.Authors) ||
+		isUserInList(ctx.ID, submission.Reviewers)
+}
+
+// checks whether a user ID belongs to a list of users
+func isUserInList(userID string, users []GlobalUser) bool {
+	for _, user := range users {
+		if user.ID == userID {
+			return true
+		}
+	}
+	return false
+}
+
 // gets all submissions which are written by a given user and returns them
 //
 // Params:
@@ -711,18 +927,60 @@ func getReviewedSubmissions(reviewerID string) (map[uint]string, error) {
 // 	(*Submission) : the data of the submission
 // 	(error) : an error if one occurs
 func getSubmission(submissionID uint) (*Submission, error) {
-	// Get data contained inside the database.
-	submission := &Submission{}
+	var submission *Submission
 	if err := gormDb.Transaction(func(tx *gorm.DB) error {
-		if res := tx.Preload(clause.Associations).Find(submission, submissionID); res.Error != nil {
-			return res.Error
-		} else if res.RowsAffected == 0 {
-			return &NoSubmissionError{ID: submissionID}
-		}
-		return nil
+		var err error
+		submission, err = loadSubmission(tx, submissionID)
+		return err
 	}); err != nil {
 		return &Submission{}, err
 	}
+	return submission, nil
+}
+
+// Gets a submission like getSubmission, in a transaction, locking its row
+// until the transaction ends so that concurrent changes to the submission
+// are made one after the other.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to lock the submission in
+// 	submissionID (uint) : the submission
+// Returns:
+// 	(*Submission) : the submission, as getSubmission returns it
+// 	(error) : a *NoSubmissionError if the submission does not exist, another error if one occurs
+func getLockedSubmission(tx *gorm.DB, submissionID uint) (*Submission, error) {
+	if res := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").Limit(1).
+		Find(&Submission{}, submissionID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoSubmissionError{ID: submissionID}
+	}
+	return loadSubmission(tx, submissionID)
+}
+
+// loads a submission with its associations, current version's files and dependencies, and metadata
+func loadSubmission(tx *gorm.DB, submissionID uint) (*Submission, error) {
+	// Get data contained inside the database.
+	submission := &Submission{}
+	if res := tx.Preload(clause.Associations).Find(submission, submissionID); res.Error != nil {
+		return nil, res.Error
+	} else if res.RowsAffected == 0 {
+		return nil, &NoSubmissionError{ID: submissionID}
+	}
+
+	// only the files and dependencies of the current version are returned
+	files, dependencies := []File{}, []Dependency{}
+	for _, file := range submission.Files {
+		if file.Version == submission.Version {
+			files = append(files, file)
+		}
+	}
+	for _, dependency := range submission.Dependencies {
+		if dependency.SubmissionVersion == submission.Version {
+			dependencies = append(dependencies, dependency)
+		}
+	}
+	submission.Files, submission.Dependencies = files, dependencies
 
 	// gets the data which is not stored in the submissions table of the database
 	var err error
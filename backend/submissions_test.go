@@ -133,7 +133,7 @@ func TestQuerySubmissions(t *testing.T) {
 			Authors:    authors,
 			Reviewers:  reviewers,
 		}
-		submissionID, err := addSubmission(submission)
+		submissionID, err := addSubmission(submission, EVENT_CREATED, "")
 		if !assert.NoError(t, err, "Error while adding test submission") {
 			return 0
 		}
@@ -481,7 +481,7 @@ func TestRouteGetSubmission(t *testing.T) {
 			Abstract: "Test",
 		},
 	}
-	id, err := addSubmission(&submission)
+	id, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -552,7 +552,7 @@ func TestRouteGetSubmission(t *testing.T) {
 	t.Run("Get approved submission as nil user", func(t *testing.T) {
 		// marks the submission approved
 		addReview(&Review{ReviewerID: globalReviewers[0].ID, Approved: true, Base64Value: "review"}, id)
-		updateSubmissionStatus(true, id)
+		updateSubmissionStatus(true, id, "", "", nil)
 
 		// sends the request
 		url := fmt.Sprintf("%s/%d", SUBROUTE_SUBMISSION, id)
@@ -618,7 +618,7 @@ func TestDownloadSubmission(t *testing.T) {
 			Abstract: "Test",
 		},
 	}
-	submissionID, err := addSubmission(&submission)
+	submissionID, err := addSubmission(&submission, EVENT_CREATED, "")
 	if !assert.NoError(t, err, "Submission creation shouldn't error!") {
 		return
 	}
@@ -710,7 +710,7 @@ func TestAddSubmission(t *testing.T) {
 	// Utility function to be re-used for testing adding submissions to the db
 	testAddSubmission := func(testSub *Submission) {
 		// adds the submission to the db and filesystem
-		_, err := addSubmission(testSub)
+		_, err := addSubmission(testSub, EVENT_CREATED, "")
 		assert.NoErrorf(t, err, "Error adding submission: %v", err)
 
 		// retrieve the submission
@@ -778,7 +778,7 @@ func TestAddSubmission(t *testing.T) {
 	// tests that trying to add a nil submission to the db and filesystem will result in an error
 	t.Run("Invalid cases do not change the database and filesystem's state", func(t *testing.T) {
 		verifyRollback := func(submission *Submission) bool {
-			_, err := addSubmission(submission)
+			_, err := addSubmission(submission, EVENT_CREATED, "")
 			if !assert.Error(t, err, "No error occured while uploading nil submission") {
 				return false
 			} else if submission != nil {
@@ -806,7 +806,7 @@ func TestAddSubmission(t *testing.T) {
 		t.Run("Invalid Runnable Submission", func(t *testing.T) {
 			notRunnable := FULL_SUBMISSION.getCopy()
 			notRunnable.Runnable = true
-			_, err := addSubmission(notRunnable)
+			_, err := addSubmission(notRunnable, EVENT_CREATED, "")
 			assert.Error(t, err, "no error for invalid runnable submission")
 		})
 	})
@@ -830,7 +830,7 @@ func TestGetSubmission(t *testing.T) {
 	testSubmission.Reviewers = globalReviewers[:1]
 
 	testSubmission.Files = []File{testFile}
-	submissionID, err := addSubmission(&testSubmission)
+	submissionID, err := addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "Error occurred while adding submission: %v", err) {
 		return
 	}
@@ -915,7 +915,7 @@ func TestGetSubmissionMetaData(t *testing.T) {
 	}
 	testSubmission.Authors = globalAuthors[:1]
 
-	submissionID, err := addSubmission(&testSubmission)
+	submissionID, err := addSubmission(&testSubmission, EVENT_CREATED, "")
 	if !assert.NoErrorf(t, err, "Error occurred while adding test submission: %v", err) {
 		return
 	}
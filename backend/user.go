@@ -34,10 +34,12 @@ func getUserSubroutes(r *mux.Router) {
 	// + POST /user/{id}/changepermissions - editor changing user permissions
 	// + POST /user/{id}/edit - edits a user profile
 	// + POST /user/{id}/delete - deletes a user profile
+	// + POST /user/{id}/chiefeditor - grant or revoke the chief editor role (in handling.go)
 	user.HandleFunc("/{id}", getUserProfile).Methods(http.MethodGet)
 	user.HandleFunc("/{id}"+ENDPOINT_CHANGE_PERMISSIONS, PostChangePermissions).Methods(http.MethodOptions, http.MethodPost)
 	user.HandleFunc("/{id}"+ENDPOINT_EDIT, PostEditUser).Methods(http.MethodOptions, http.MethodPost)
 	user.HandleFunc("/{id}"+ENDPOINT_DELETE, PostDeleteUser).Methods(http.MethodOptions, http.MethodPost)
+	user.HandleFunc("/{id}"+ENDPOINT_CHIEF_EDITOR, PostSetChiefEditor).Methods(http.MethodOptions, http.MethodPost)
 
 	// Users routes:
 	// + GET /users/query
@@ -72,6 +74,26 @@ func getUserProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// submissions reviewed under blind review are only listed for editors and the user themselves
+	ctx, _ := r.Context().Value("data").(*RequestContext)
+	if ctx == nil || (ctx.UserType != USERTYPE_EDITOR && ctx.ID != user.ID) {
+		settings, err := getJournalSettings(gormDb)
+		if err != nil {
+			log.Printf("[ERROR] could not get journal settings: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		user.ReviewedSubmissions = filterBlindReviewedSubmissions(user.ReviewedSubmissions, settings.ReviewMode)
+	}
+	// nor are the submissions the user wrote which the viewer reviews under double-blind review
+	authored, err := filterBlindAuthoredSubmissions(gormDb, user.AuthoredSubmissions, ctx)
+	if err != nil {
+		log.Printf("[ERROR] could not filter authored submissions: %v", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	user.AuthoredSubmissions = authored
+
 	// Encode user and send.
 	if err := json.NewEncoder(w).Encode(user); err != nil {
 		log.Printf("[ERROR] User data JSON encoding failed: %v", err)
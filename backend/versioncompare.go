@@ -0,0 +1,279 @@
+// =========================================================================
+// versioncompare.go
+// Created: October 19, 2026
+//
+// This file takes care of ordering package versions as each ecosystem does
+// (semantic versioning, PEP 440 and Maven versions)
+// =========================================================================
+
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// PEP 440 version (epoch, release, pre-release, post-release and dev-release)
+var pep440Matcher = regexp.MustCompile(`^v?(?:(\d+)!)?(\d+(?:\.\d+)*)` +
+	`(?:[-_.]?(a|b|c|rc|alpha|beta|pre|preview)[-_.]?(\d*))?` +
+	`(?:-(\d+)|[-_.]?(post|rev|r)[-_.]?(\d*))?` +
+	`(?:[-_.]?(dev)[-_.]?(\d*))?(?:\+.*)?$`)
+
+// ordering of PEP 440 pre-release phases
+var pep440Phases = map[string]int{"a": 0, "alpha": 0, "b": 1, "beta": 1, "c": 2, "rc": 2, "pre": 2, "preview": 2}
+
+// ordering of well-known Maven qualifiers, a release being "" (see Maven's ComparableVersion)
+var mavenQualifiers = map[string]int{
+	"alpha": 0, "a": 0, "beta": 1, "b": 1, "milestone": 2, "m": 2, "rc": 3, "cr": 3,
+	"snapshot": 4, "": 5, "ga": 5, "final": 5, "release": 5, "sp": 6,
+}
+
+// Compares two versions using an ecosystem's ordering.
+//
+// Params:
+// 	ecosystem (string) : the ecosystem the versions belong to
+// 	a (string) : the first version
+// 	b (string) : the second version
+// Returns:
+// 	(int) : -1 if a < b, 0 if a == b and 1 if a > b
+func compareVersions(ecosystem string, a string, b string) int {
+	switch ecosystem {
+	case ECOSYSTEM_PYPI:
+		return comparePep440(a, b)
+	case ECOSYSTEM_MAVEN:
+		return compareMaven(a, b)
+	default:
+		return compareSemver(a, b)
+	}
+}
+
+// Compares two semantic versions (see semver.org). Missing minor or patch
+// numbers count as 0 and a leading "v" is ignored.
+func compareSemver(a string, b string) int {
+	aCore, aPre := splitSemver(a)
+	bCore, bPre := splitSemver(b)
+	for i := 0; i < len(aCore) || i < len(bCore); i++ {
+		if c := compareInts(numberAt(aCore, i), numberAt(bCore, i)); c != 0 {
+			return c
+		}
+	}
+
+	// a version without a pre-release is greater than one with
+	switch {
+	case aPre == "" && bPre == "":
+		return 0
+	case aPre == "":
+		return 1
+	case bPre == "":
+		return -1
+	}
+	aIdentifiers, bIdentifiers := strings.Split(aPre, "."), strings.Split(bPre, ".")
+	for i := 0; i < len(aIdentifiers) && i < len(bIdentifiers); i++ {
+		aNumber, aErr := strconv.Atoi(aIdentifiers[i])
+		bNumber, bErr := strconv.Atoi(bIdentifiers[i])
+		var c int
+		switch {
+		case aErr == nil && bErr == nil:
+			c = compareInts(aNumber, bNumber)
+		case aErr == nil: // numeric identifiers are lower than alphanumeric ones
+			c = -1
+		case bErr == nil:
+			c = 1
+		default:
+			c = strings.Compare(aIdentifiers[i], bIdentifiers[i])
+		}
+		if c != 0 {
+			return c
+		}
+	}
+	return compareInts(len(aIdentifiers), len(bIdentifiers))
+}
+
+// splits a semantic version into its numbers and pre-release, dropping build metadata
+func splitSemver(version string) ([]string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if index := strings.Index(version, "+"); index != -1 {
+		version = version[:index]
+	}
+	pre := ""
+	if index := strings.Index(version, "-"); index != -1 {
+		version, pre = version[:index], version[index+1:]
+	}
+	return strings.Split(version, "."), pre
+}
+
+// Compares two python package versions (see PEP 440). Versions which are not
+// valid PEP 440 versions fall back on semantic version ordering.
+func comparePep440(a string, b string) int {
+	aMatch := pep440Matcher.FindStringSubmatch(strings.ToLower(strings.TrimSpace(a)))
+	bMatch := pep440Matcher.FindStringSubmatch(strings.ToLower(strings.TrimSpace(b)))
+	if aMatch == nil || bMatch == nil {
+		return compareSemver(a, b)
+	}
+	aEpoch, aRelease, aSuffix := pep440Key(aMatch)
+	bEpoch, bRelease, bSuffix := pep440Key(bMatch)
+	if c := compareInts(aEpoch, bEpoch); c != 0 {
+		return c
+	}
+	for i := 0; i < len(aRelease) || i < len(bRelease); i++ {
+		if c := compareInts(intAt(aRelease, i), intAt(bRelease, i)); c != 0 {
+			return c
+		}
+	}
+	for i := range aSuffix {
+		if c := compareInts(aSuffix[i], bSuffix[i]); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// Splits a matched PEP 440 version into its epoch, release numbers and a sort
+// key for its suffixes (pre-release phase and number, post-release, dev-release).
+func pep440Key(match []string) (int, []int, []int) {
+	epoch, _ := strconv.Atoi(match[1])
+	release := []int{}
+	for _, number := range strings.Split(match[2], ".") {
+		n, _ := strconv.Atoi(number)
+		release = append(release, n)
+	}
+
+	const none, infinite = -1, 1 << 30
+	phase, preNumber, post, dev := infinite, 0, none, infinite
+	if match[3] != "" {
+		phase = pep440Phases[match[3]]
+		preNumber, _ = strconv.Atoi(match[4])
+	}
+	if match[5] != "" {
+		post, _ = strconv.Atoi(match[5])
+	} else if match[6] != "" {
+		post, _ = strconv.Atoi(match[7])
+	}
+	if match[8] != "" {
+		dev, _ = strconv.Atoi(match[9])
+		// a dev release of a final release comes before its pre-releases
+		if match[3] == "" && post == none {
+			phase = none
+		}
+	}
+	return epoch, release, []int{phase, preNumber, post, dev}
+}
+
+// Compares two Maven versions, splitting them into numeric and qualifier
+// items as Maven does. Numbers are greater than qualifiers.
+func compareMaven(a string, b string) int {
+	aItems, bItems := mavenItems(a), mavenItems(b)
+	for i := 0; i < len(aItems) || i < len(bItems); i++ {
+		aItem, bItem := "", ""
+		if i < len(aItems) {
+			aItem = aItems[i]
+		}
+		if i < len(bItems) {
+			bItem = bItems[i]
+		}
+		if c := compareMavenItems(aItem, bItem); c != 0 {
+			return c
+		}
+	}
+	return 0
+}
+
+// splits a Maven version on separators and on transitions between digits and letters
+func mavenItems(version string) []string {
+	items := []string{}
+	current := ""
+	for _, c := range strings.ToLower(strings.TrimSpace(version)) {
+		if c == '.' || c == '-' || c == '_' {
+			items = append(items, current)
+			current = ""
+			continue
+		}
+		if current != "" && isDigit(rune(current[len(current)-1])) != isDigit(c) {
+			items = append(items, current)
+			current = ""
+		}
+		current += string(c)
+	}
+	items = append(items, current)
+
+	// trailing zeros and release qualifiers do not change a version (1.0 == 1 == 1-final)
+	for len(items) > 1 {
+		last := items[len(items)-1]
+		if n, err := strconv.Atoi(last); err == nil && n != 0 {
+			break
+		} else if rank, ok := mavenQualifiers[last]; err != nil && (!ok || rank != mavenQualifiers[""]) {
+			break
+		}
+		items = items[:len(items)-1]
+	}
+	return items
+}
+
+// compares single Maven version items, a missing item counting as a release
+func compareMavenItems(a string, b string) int {
+	aNumber, aErr := strconv.Atoi(a)
+	bNumber, bErr := strconv.Atoi(b)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(aNumber, bNumber)
+	case aErr == nil:
+		if b == "" {
+			return compareInts(aNumber, 0)
+		}
+		return 1
+	case bErr == nil:
+		if a == "" {
+			return compareInts(0, bNumber)
+		}
+		return -1
+	}
+	aRank, aKnown := mavenQualifiers[a]
+	bRank, bKnown := mavenQualifiers[b]
+	switch {
+	case aKnown && bKnown:
+		return compareInts(aRank, bRank)
+	case aKnown: // unknown qualifiers come after all known ones
+		return -1
+	case bKnown:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// ------
+// Helper Functions
+// ------
+
+// compares two integers
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+// gets the number at an index of a list of numeric strings, 0 if out of range or not a number
+func numberAt(numbers []string, i int) int {
+	if i >= len(numbers) {
+		return 0
+	}
+	n, _ := strconv.Atoi(numbers[i])
+	return n
+}
+
+// gets the integer at an index of a list, 0 if out of range
+func intAt(numbers []int, i int) int {
+	if i >= len(numbers) {
+		return 0
+	}
+	return numbers[i]
+}
+
+// checks whether a character is an ASCII digit
+func isDigit(c rune) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,58 @@
+// =====================================
+// versioncompare_test.go
+// Created: October 19, 2026
+//
+// test file for versioncompare.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// Tests that versions are ordered as each ecosystem orders them
+func TestCompareVersions(t *testing.T) {
+	testCases := []struct {
+		ecosystem string
+		lower     string
+		higher    string
+	}{
+		// semantic versions
+		{ECOSYSTEM_GO, "v1.2.3", "v1.10.0"},
+		{ECOSYSTEM_NPM, "1.0.0-alpha", "1.0.0-alpha.1"},
+		{ECOSYSTEM_NPM, "1.0.0-alpha.1", "1.0.0-beta"},
+		{ECOSYSTEM_NPM, "1.0.0-rc.1", "1.0.0"},
+		{ECOSYSTEM_CARGO, "0.8", "0.8.1"},
+		// PEP 440
+		{ECOSYSTEM_PYPI, "1.0.dev1", "1.0a1"},
+		{ECOSYSTEM_PYPI, "1.0a1", "1.0b2"},
+		{ECOSYSTEM_PYPI, "1.0rc1", "1.0"},
+		{ECOSYSTEM_PYPI, "1.0", "1.0.post1"},
+		{ECOSYSTEM_PYPI, "2.9.9", "2.10"},
+		{ECOSYSTEM_PYPI, "2.0", "1!0.1"},
+		// Maven
+		{ECOSYSTEM_MAVEN, "1.0-SNAPSHOT", "1.0"},
+		{ECOSYSTEM_MAVEN, "1.0-alpha-1", "1.0-beta-1"},
+		{ECOSYSTEM_MAVEN, "1.0-rc1", "1.0"},
+		{ECOSYSTEM_MAVEN, "2.9.10", "2.9.10.1"},
+		{ECOSYSTEM_MAVEN, "31.0", "31.0.1-jre"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, -1, compareVersions(testCase.ecosystem, testCase.lower, testCase.higher),
+			"%s: %s should be lower than %s", testCase.ecosystem, testCase.lower, testCase.higher)
+		assert.Equal(t, 1, compareVersions(testCase.ecosystem, testCase.higher, testCase.lower),
+			"%s: %s should be higher than %s", testCase.ecosystem, testCase.higher, testCase.lower)
+	}
+
+	// equivalent versions
+	assert.Equal(t, 0, compareVersions(ECOSYSTEM_PYPI, "1.0", "1.0.0"), "Trailing zeros changed PyPI version")
+	assert.Equal(t, 0, compareVersions(ECOSYSTEM_MAVEN, "1.0", "1-final"), "Release qualifier changed Maven version")
+	assert.Equal(t, 0, compareVersions(ECOSYSTEM_GO, "v1.2.3", "1.2.3+incompatible"), "Build metadata changed semantic version")
+}
@@ -0,0 +1,308 @@
+// =========================================================================
+// vulnerabilities.go
+// Created: October 19, 2026
+//
+// This file takes care of matching submissions' pinned dependencies against
+// the imported security advisories and reporting the vulnerabilities found
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_VULNERABILITIES = "/vulnerabilities"
+)
+
+// ------
+// Router Functions
+// ------
+
+// router function to get the vulnerability report of a submission. Only
+// authors, reviewers and editors are allowed to see it. The report of the
+// current version is given unless a version is given as a query parameter
+// GET /submission/{id}/vulnerabilities?version={version}
+func GetSubmissionVulnerabilities(w http.ResponseWriter, r *http.Request) {
+	resp := &GetSubmissionVulnerabilitiesResponse{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	version64, versionErr := uint64(0), error(nil)
+	if versionParam := r.URL.Query().Get("version"); versionParam != "" {
+		version64, versionErr = strconv.ParseUint(versionParam, 10, 32)
+	}
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if versionErr != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given version not a positive number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if resp.Vulnerabilities, err = getVulnerabilityReport(submissionID, uint(version64), ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError, *NoSubmissionVersionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Only authors, reviewers and editors can view a submission's vulnerability report.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		default:
+			log.Printf("[ERROR] could not get vulnerability report: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not get vulnerability report", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// ------
+// Helper Functions
+// ------
+
+// gets the vulnerabilities of a version of a submission, most severe first,
+// checking that the user is one of its authors, reviewers or an editor. A
+// version of 0 gives the current version
+func getVulnerabilityReport(submissionID uint, version uint, ctx *RequestContext) ([]Vulnerability, error) {
+	vulnerabilities := []Vulnerability{}
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		submission := &Submission{}
+		if res := tx.Preload("Authors").Preload("Reviewers").Limit(1).Find(submission, submissionID); res.Error != nil {
+			return res.Error
+		} else if res.RowsAffected == 0 {
+			return &NoSubmissionError{ID: submissionID}
+		}
+		if ctx.UserType != USERTYPE_EDITOR && !isUserInList(ctx.ID, submission.Authors) &&
+			!isUserInList(ctx.ID, submission.Reviewers) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		}
+		if version == 0 {
+			version = submission.Version
+		} else if version > submission.Version {
+			return &NoSubmissionVersionError{SubmissionID: submissionID, Version: version}
+		}
+		return tx.Where("submission_id = ? AND submission_version = ?", submissionID, version).Find(&vulnerabilities).Error
+	}); err != nil {
+		return nil, err
+	}
+	sortVulnerabilities(vulnerabilities)
+	return vulnerabilities, nil
+}
+
+// Counts the vulnerabilities of a version of a submission by severity.
+//
+// Params:
+// 	submissionID (uint) : the submission to count the vulnerabilities of
+// 	version (uint) : the version of the submission
+// Returns:
+// 	(map[string]int) : the number of vulnerabilities per severity level, nil if there are none
+// 	(error) : an error if one occurs
+func getVulnerabilityCounts(submissionID uint, version uint) (map[string]int, error) {
+	counts := []struct {
+		Severity string
+		Count    int
+	}{}
+	if err := gormDb.Model(&Vulnerability{}).Select("severity, count(*) as count").
+		Where("submission_id = ? AND submission_version = ?", submissionID, version).Group("severity").Scan(&counts).Error; err != nil {
+		return nil, err
+	} else if len(counts) == 0 {
+		return nil, nil
+	}
+	vulnerabilityCounts := make(map[string]int)
+	for _, count := range counts {
+		vulnerabilityCounts[count.Severity] = count.Count
+	}
+	return vulnerabilityCounts, nil
+}
+
+// Matches the pinned dependencies of a version of a submission against the
+// stored advisories, replacing the version's previous vulnerability report.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to evaluate the submission in
+// 	submissionID (uint) : the submission to evaluate
+// 	version (uint) : the version of the submission to evaluate
+// Returns:
+// 	(error) : an error if one occurs
+func evaluateVulnerabilities(tx *gorm.DB, submissionID uint, version uint) error {
+	if err := tx.Where("submission_id = ? AND submission_version = ?", submissionID, version).Delete(&Vulnerability{}).Error; err != nil {
+		return err
+	}
+	dependencies := []Dependency{}
+	if err := tx.Where("submission_id = ? AND submission_version = ? AND pinned = ?", submissionID, version, true).
+		Find(&dependencies).Error; err != nil {
+		return err
+	} else if len(dependencies) == 0 {
+		return nil
+	}
+
+	// gets the affected ranges of the dependencies' packages along with their advisories
+	names := []string{}
+	for _, dependency := range dependencies {
+		names = append(names, dependency.Name)
+	}
+	affected := []AffectedPackage{}
+	if err := tx.Where("name IN ?", names).Find(&affected).Error; err != nil {
+		return err
+	} else if len(affected) == 0 {
+		return nil
+	}
+	advisoryIDs := []string{}
+	for _, affectedPackage := range affected {
+		advisoryIDs = append(advisoryIDs, affectedPackage.AdvisoryID)
+	}
+	advisories := []Advisory{}
+	if err := tx.Where("id IN ?", advisoryIDs).Find(&advisories).Error; err != nil {
+		return err
+	}
+	advisoriesByID := make(map[string]Advisory)
+	for _, advisory := range advisories {
+		advisoriesByID[advisory.ID] = advisory
+	}
+
+	vulnerabilities := matchVulnerabilities(dependencies, affected, advisoriesByID)
+	if len(vulnerabilities) == 0 {
+		return nil
+	}
+	for i := range vulnerabilities {
+		vulnerabilities[i].SubmissionID = submissionID
+		vulnerabilities[i].SubmissionVersion = version
+	}
+	return tx.Create(&vulnerabilities).Error
+}
+
+// Re-evaluates the vulnerabilities of every submission version with
+// dependencies, called whenever the stored advisories change.
+func reevaluateVulnerabilities(tx *gorm.DB) error {
+	if err := tx.Session(&gorm.Session{AllowGlobalUpdate: true}).Delete(&Vulnerability{}).Error; err != nil {
+		return err
+	}
+	versions := []struct {
+		SubmissionID      uint
+		SubmissionVersion uint
+	}{}
+	if err := tx.Model(&Dependency{}).Distinct("submission_id", "submission_version").
+		Where("pinned = ?", true).Scan(&versions).Error; err != nil {
+		return err
+	}
+	for _, version := range versions {
+		if err := evaluateVulnerabilities(tx, version.SubmissionID, version.SubmissionVersion); err != nil {
+			return err
+		}
+	}
+	log.Printf("[INFO] Re-evaluated vulnerabilities of %d submission versions", len(versions))
+	return nil
+}
+
+// Matches pinned dependencies against affected package ranges, giving one
+// vulnerability per dependency and advisory.
+//
+// Params:
+// 	dependencies ([]Dependency) : the pinned dependencies to match
+// 	affected ([]AffectedPackage) : the affected ranges of the dependencies' packages
+// 	advisories (map[string]Advisory) : the advisories of the affected ranges by ID
+// Returns:
+// 	([]Vulnerability) : the vulnerabilities found, without submission ID set
+func matchVulnerabilities(dependencies []Dependency, affected []AffectedPackage, advisories map[string]Advisory) []Vulnerability {
+	vulnerabilities := []Vulnerability{}
+	for _, dependency := range dependencies {
+		matched := make(map[string]bool)
+		fixedIn := make(map[string][]string)
+		for _, affectedPackage := range affected {
+			if affectedPackage.Ecosystem != dependency.Ecosystem || affectedPackage.Name != dependency.Name {
+				continue
+			}
+			if affectedPackage.Fixed != "" {
+				fixedIn[affectedPackage.AdvisoryID] = append(fixedIn[affectedPackage.AdvisoryID], affectedPackage.Fixed)
+			}
+			if isVersionAffected(dependency.Ecosystem, dependency.Version, affectedPackage) {
+				matched[affectedPackage.AdvisoryID] = true
+			}
+		}
+		for advisoryID := range matched {
+			advisory := advisories[advisoryID]
+			fixes := fixedIn[advisoryID]
+			sort.Slice(fixes, func(i, j int) bool {
+				return compareVersions(dependency.Ecosystem, fixes[i], fixes[j]) < 0
+			})
+			vulnerabilities = append(vulnerabilities, Vulnerability{
+				DependencyID: dependency.ID,
+				AdvisoryID:   advisoryID,
+				Ecosystem:    dependency.Ecosystem,
+				Package:      dependency.Name,
+				Version:      dependency.Version,
+				Manifest:     dependency.Manifest,
+				Severity:     advisory.Severity,
+				Summary:      advisory.Summary,
+				FixedIn:      strings.Join(fixes, ", "),
+			})
+		}
+	}
+	sortVulnerabilities(vulnerabilities)
+	return vulnerabilities
+}
+
+// Checks whether a version falls within an affected range, or is one of the
+// versions it lists explicitly.
+//
+// Params:
+// 	ecosystem (string) : the ecosystem the version belongs to
+// 	version (string) : the exact version to check
+// 	affected (AffectedPackage) : the affected range
+// Returns:
+// 	(bool) : whether the version is affected
+func isVersionAffected(ecosystem string, version string, affected AffectedPackage) bool {
+	compare := func(a string, b string) int { return compareVersions(ecosystem, a, b) }
+	if affected.RangeType == RANGE_SEMVER {
+		compare = compareSemver
+	}
+	if affected.Versions != "" {
+		for _, affectedVersion := range strings.Split(affected.Versions, "\n") {
+			if compare(version, affectedVersion) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if affected.Introduced != "" && affected.Introduced != "0" && compare(version, affected.Introduced) < 0 {
+		return false
+	} else if affected.Fixed != "" && compare(version, affected.Fixed) >= 0 {
+		return false
+	} else if affected.LastAffected != "" && compare(version, affected.LastAffected) > 0 {
+		return false
+	}
+	return true
+}
+
+// sorts vulnerabilities by severity, then package and advisory
+func sortVulnerabilities(vulnerabilities []Vulnerability) {
+	sort.SliceStable(vulnerabilities, func(i, j int) bool {
+		a, b := vulnerabilities[i], vulnerabilities[j]
+		if severityRanks[a.Severity] != severityRanks[b.Severity] {
+			return severityRanks[a.Severity] < severityRanks[b.Severity]
+		} else if a.Package != b.Package {
+			return a.Package < b.Package
+		}
+		return a.AdvisoryID < b.AdvisoryID
+	})
+}
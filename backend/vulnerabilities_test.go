@@ -0,0 +1,149 @@
+// =====================================
+// vulnerabilities_test.go
+// Created: October 19, 2026
+//
+// test file for vulnerabilities.go and advisories.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	TEST_OSV_DUMP = `[{
+		"id": "GHSA-test-0001",
+		"summary": "Denial of service in example-lib",
+		"aliases": ["CVE-2021-0001"],
+		"modified": "2021-06-01T00:00:00Z",
+		"severity": [{"type": "CVSS_V3", "score": "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H"}],
+		"affected": [{
+			"package": {"ecosystem": "PyPI", "name": "Example_Lib"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [
+				{"introduced": "0"}, {"fixed": "1.2.0"},
+				{"introduced": "2.0"}, {"fixed": "2.0.3"}
+			]}]
+		}]
+	}, {
+		"id": "GHSA-test-0002",
+		"details": "Prototype pollution in widget\nMore details.",
+		"modified": "2021-06-01T00:00:00Z",
+		"database_specific": {"severity": "MODERATE"},
+		"affected": [{
+			"package": {"ecosystem": "npm", "name": "widget"},
+			"ranges": [{"type": "SEMVER", "events": [{"introduced": "1.0.0"}, {"last_affected": "1.4.2"}]}],
+			"versions": ["0.9.1"]
+		}, {
+			"package": {"ecosystem": "Debian:11", "name": "widget"},
+			"ranges": [{"type": "ECOSYSTEM", "events": [{"introduced": "0"}]}]
+		}]
+	}, {
+		"id": "GHSA-test-0003",
+		"modified": "2021-06-01T00:00:00Z",
+		"withdrawn": "2021-07-01T00:00:00Z",
+		"affected": []
+	}]`
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// Tests that OSV advisories are converted into affected version intervals
+func TestConvertOsvAdvisory(t *testing.T) {
+	osvAdvisories, err := decodeOsvFile([]byte(TEST_OSV_DUMP))
+	switch {
+	case !assert.NoError(t, err, "Error decoding OSV dump"):
+		return
+	case !assert.Len(t, osvAdvisories, 3, "Wrong number of advisories decoded"):
+		return
+	case !assert.NotNil(t, osvAdvisories[2].Withdrawn, "Withdrawn advisory not detected"):
+		return
+	}
+
+	advisory := convertOsvAdvisory(osvAdvisories[0])
+	switch {
+	case !assert.Equal(t, SEVERITY_CRITICAL, advisory.Severity, "Severity not computed from CVSS vector"):
+		return
+	case !assert.Equal(t, "CVE-2021-0001", advisory.Aliases, "Aliases not kept"):
+		return
+	case !assert.Len(t, advisory.Affected, 2, "Range not split into intervals"):
+		return
+	case !assert.Equal(t, "example-lib", advisory.Affected[0].Name, "PyPI package name not normalised"):
+		return
+	case !assert.Equal(t, "2.0.3", advisory.Affected[1].Fixed, "Wrong fixed version on second interval"):
+		return
+	}
+
+	advisory = convertOsvAdvisory(osvAdvisories[1])
+	switch {
+	case !assert.Equal(t, SEVERITY_MODERATE, advisory.Severity, "Database specific severity not used"):
+		return
+	case !assert.Equal(t, "Prototype pollution in widget", advisory.Summary, "Summary not taken from details"):
+		return
+	case !assert.Len(t, advisory.Affected, 2, "Unsupported ecosystem not skipped"):
+		return
+	case !assert.Equal(t, "0.9.1", advisory.Affected[0].Versions, "Explicit versions not kept"):
+		return
+	}
+}
+
+// Tests CVSS v3 base scores against the scores given by the CVSS calculator
+func TestCvss3BaseScore(t *testing.T) {
+	testCases := map[string]float64{
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H": 9.8,
+		"CVSS:3.1/AV:N/AC:L/PR:L/UI:N/S:C/C:L/I:L/A:N": 6.4,
+		"CVSS:3.0/AV:L/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N": 1.8,
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N": 0,
+	}
+	for vector, expected := range testCases {
+		score, ok := cvss3BaseScore(vector)
+		assert.True(t, ok, "Valid vector %s not scored", vector)
+		assert.Equal(t, expected, score, "Wrong score for %s", vector)
+	}
+	_, ok := cvss3BaseScore("CVSS:2.0/AV:N/AC:L/Au:N/C:P/I:P/A:P")
+	assert.False(t, ok, "CVSS v2 vector scored")
+	_, ok = cvss3BaseScore("CVSS:3.1/AV:N/AC:L")
+	assert.False(t, ok, "Incomplete vector scored")
+}
+
+// Tests that only affected pinned versions are matched
+func TestMatchVulnerabilities(t *testing.T) {
+	osvAdvisories, err := decodeOsvFile([]byte(TEST_OSV_DUMP))
+	if !assert.NoError(t, err, "Error decoding OSV dump") {
+		return
+	}
+	advisories := make(map[string]Advisory)
+	affected := []AffectedPackage{}
+	for _, osvAdvisory := range osvAdvisories {
+		advisory := convertOsvAdvisory(osvAdvisory)
+		advisories[advisory.ID] = advisory
+		affected = append(affected, advisory.Affected...)
+	}
+
+	testCases := []struct {
+		dependency Dependency
+		advisories []string
+		fixedIn    string
+	}{
+		{Dependency{Ecosystem: ECOSYSTEM_PYPI, Name: "example-lib", Version: "1.1.9"}, []string{"GHSA-test-0001"}, "1.2.0, 2.0.3"},
+		{Dependency{Ecosystem: ECOSYSTEM_PYPI, Name: "example-lib", Version: "1.2.0"}, []string{}, ""},
+		{Dependency{Ecosystem: ECOSYSTEM_PYPI, Name: "example-lib", Version: "2.0.2"}, []string{"GHSA-test-0001"}, "1.2.0, 2.0.3"},
+		{Dependency{Ecosystem: ECOSYSTEM_NPM, Name: "widget", Version: "1.4.2"}, []string{"GHSA-test-0002"}, ""},
+		{Dependency{Ecosystem: ECOSYSTEM_NPM, Name: "widget", Version: "1.4.3"}, []string{}, ""},
+		{Dependency{Ecosystem: ECOSYSTEM_NPM, Name: "widget", Version: "0.9.1"}, []string{"GHSA-test-0002"}, ""},
+		{Dependency{Ecosystem: ECOSYSTEM_CARGO, Name: "widget", Version: "1.0.0"}, []string{}, ""},
+	}
+	for _, testCase := range testCases {
+		vulnerabilities := matchVulnerabilities([]Dependency{testCase.dependency}, affected, advisories)
+		matched := []string{}
+		for _, vulnerability := range vulnerabilities {
+			matched = append(matched, vulnerability.AdvisoryID)
+			assert.Equal(t, testCase.fixedIn, vulnerability.FixedIn, "Wrong fixed versions for %v", testCase.dependency)
+		}
+		assert.ElementsMatch(t, testCase.advisories, matched, "Wrong advisories matched for %v", testCase.dependency)
+	}
+}
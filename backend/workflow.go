@@ -0,0 +1,399 @@
+// =========================================================================
+// workflow.go
+// Created: October 19, 2026
+//
+// This file takes care of the submission review workflow: the states a
+// submission goes through, the transitions allowed between them and
+// resubmission of new versions after revisions are requested
+// =========================================================================
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const (
+	ENDPOINT_STATUS   = "/status"
+	ENDPOINT_RESUBMIT = "/resubmit"
+
+	// submission statuses
+	STATUS_DRAFT               = "draft"
+	STATUS_SUBMITTED           = "submitted"
+	STATUS_UNDER_REVIEW        = "under_review"
+	STATUS_REVISIONS_REQUESTED = "revisions_requested"
+	STATUS_RESUBMITTED         = "resubmitted"
+	STATUS_ACCEPTED            = "accepted"
+	STATUS_REJECTED            = "rejected"
+	STATUS_WITHDRAWN           = "withdrawn"
+)
+
+// statuses each status can lead to. Statuses absent from the map are final
+var statusTransitions = map[string][]string{
+	STATUS_DRAFT:               {STATUS_SUBMITTED, STATUS_WITHDRAWN},
+	STATUS_SUBMITTED:           {STATUS_UNDER_REVIEW, STATUS_REJECTED, STATUS_WITHDRAWN},
+	STATUS_UNDER_REVIEW:        {STATUS_REVISIONS_REQUESTED, STATUS_ACCEPTED, STATUS_REJECTED, STATUS_WITHDRAWN},
+	STATUS_REVISIONS_REQUESTED: {STATUS_RESUBMITTED, STATUS_REJECTED, STATUS_WITHDRAWN},
+	STATUS_RESUBMITTED:         {STATUS_UNDER_REVIEW, STATUS_REJECTED, STATUS_WITHDRAWN},
+}
+
+// statuses set by a submission's authors, all others being set by editors
+// (apart from resubmitted, which is only set by uploading a new version)
+var authorStatuses = map[string]bool{STATUS_SUBMITTED: true, STATUS_WITHDRAWN: true}
+
+// ------
+// Router Functions
+// ------
+
+// router function to move a submission through the review workflow. Authors
+// can submit drafts and withdraw submissions, editors make all other changes
+// POST /submission/{id}/status
+func PostChangeSubmissionStatus(w http.ResponseWriter, r *http.Request) {
+	resp := &StandardResponse{Message: "Submission status updated successfully", Error: false}
+	reqBody := &ChangeSubmissionStatusBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp = &StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp = &StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil || validate.Struct(reqBody) != nil {
+		resp = &StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if err := ControllerChangeSubmissionStatus(reqBody.Status, reqBody.Justification,
+		&reqBody.DecisionLetterBody, submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp = &StandardResponse{Message: "Not authorized to set the submission to this status.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case *NotHandlingEditorError, *MissingReviewsError, *MissingApprovalError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case *InvalidStatusTransitionError, *MissingJustificationError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		case *NoLetterTemplateError:
+			resp = &StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			log.Printf("[ERROR] could not change submission status: %v\n", err)
+			resp = &StandardResponse{Message: "Internal Server Error - could not change submission status", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// router function for authors to upload a new version of a submission after
+// revisions were requested. The submission's reviewers review the new version
+// POST /submission/{id}/resubmit
+func PostResubmitSubmission(w http.ResponseWriter, r *http.Request) {
+	resp := &ResubmitSubmissionResponse{}
+	reqBody := &ResubmitSubmissionBody{}
+
+	params := mux.Vars(r)
+	submissionID64, err := strconv.ParseUint(params["id"], 10, 32)
+	submissionID := uint(submissionID64)
+	if err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Given Submission ID not a number.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if ctx, ok := r.Context().Value("data").(*RequestContext); !ok || validate.Struct(ctx) != nil {
+		resp.StandardResponse = StandardResponse{Message: "Request Context not set, user not logged in.", Error: true}
+		w.WriteHeader(http.StatusUnauthorized)
+
+	} else if err := json.NewDecoder(r.Body).Decode(reqBody); err != nil {
+		resp.StandardResponse = StandardResponse{Message: "Unable to parse request body.", Error: true}
+		w.WriteHeader(http.StatusBadRequest)
+
+	} else if resp.Version, err = ControllerResubmitSubmission(reqBody, submissionID, ctx); err != nil {
+		switch err.(type) {
+		case *NoSubmissionError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusNotFound)
+		case *WrongPermissionsError:
+			resp.StandardResponse = StandardResponse{Message: "Only authors can resubmit a submission.", Error: true}
+			w.WriteHeader(http.StatusUnauthorized)
+		case validator.ValidationErrors:
+			resp.StandardResponse = StandardResponse{Message: fmt.Sprintf("Bad fields inserted - %v", err.Error()), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		case *InvalidStatusTransitionError, *SecretsFoundError, *RejectedFileError, *DuplicateFileError:
+			resp.StandardResponse = StandardResponse{Message: err.Error(), Error: true}
+			w.WriteHeader(http.StatusBadRequest)
+		default:
+			log.Printf("[ERROR] could not resubmit submission: %v\n", err)
+			resp.StandardResponse = StandardResponse{Message: "Internal Server Error - could not resubmit submission", Error: true}
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	} else {
+		resp.StandardResponse = StandardResponse{Message: "Submission resubmitted successfully", Error: false}
+	}
+
+	// sends a response to the client
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		log.Printf("[ERROR] error formatting response: %v\n", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Controller which moves a submission to a new status, checking that the user
+// is allowed to make the change and that the transition is valid. Accepting
+// and rejecting are recorded as decisions.
+//
+// Params:
+// 	status (string) : the status to move the submission to
+// 	justification (string) : the editor's justification for accepting or rejecting
+// 	letter (*DecisionLetterBody) : the editor's letter to the authors when accepting or rejecting
+// 	submissionID (uint) : the submission to update
+// 	ctx (*RequestContext) : the logged in user
+// Returns:
+// 	(error) : an error if the change is not allowed or fails
+func ControllerChangeSubmissionStatus(status string, justification string, letter *DecisionLetterBody, submissionID uint, ctx *RequestContext) error {
+	return gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that concurrent changes cannot both pass the transition checks
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		} else if authorStatuses[status] && !isUserInList(ctx.ID, submission.Authors) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		} else if !authorStatuses[status] && ctx.UserType != USERTYPE_EDITOR {
+			return &WrongPermissionsError{userID: ctx.ID}
+		}
+		if status == STATUS_ACCEPTED || status == STATUS_REJECTED {
+			return decideSubmission(tx, submission, status, ctx.ID, justification, letter)
+		} else if !authorStatuses[status] {
+			if err := checkHandlingEditor(tx, submission, ctx.ID); err != nil {
+				return err
+			}
+		}
+		return setSubmissionStatus(tx, submission, status, ctx.ID)
+	})
+}
+
+// Controller which uploads a new version of a submission from a zip file. The
+// previous versions' files are kept, their comments are moved onto the new
+// version's files, and the reviewers stay assigned.
+//
+// Params:
+// 	r (*ResubmitSubmissionBody) : the new version's zip file
+// 	submissionID (uint) : the submission to resubmit
+// 	ctx (*RequestContext) : the logged in user, who must be an author
+// Returns:
+// 	(uint) : the new version of the submission
+// 	(error) : an error if one occurs
+func ControllerResubmitSubmission(r *ResubmitSubmissionBody, submissionID uint, ctx *RequestContext) (uint, error) {
+	if err := validate.Struct(r); err != nil {
+		return 0, err
+	}
+	var version uint
+	if err := gormDb.Transaction(func(tx *gorm.DB) error {
+		// the submission is locked so that concurrent resubmissions get different versions
+		submission, err := getLockedSubmission(tx, submissionID)
+		if err != nil {
+			return err
+		} else if !isUserInList(ctx.ID, submission.Authors) {
+			return &WrongPermissionsError{userID: ctx.ID}
+		}
+		if err := setSubmissionStatus(tx, submission, STATUS_RESUBMITTED, ctx.ID); err != nil {
+			return err
+		}
+		files, err := getFileArrayFromZipBase64(r.ZipBase64Value)
+		if err != nil {
+			return err
+		}
+
+		// only the current version of a submission is compared for similarity
+		if err := tx.Where("submission_id = ?", submissionID).Delete(&Fingerprint{}).Error; err != nil {
+			return err
+		}
+		submission.Version++
+		submission.Files = files
+		version = submission.Version
+		if err := tx.Model(submission).Update("version", submission.Version).Error; err != nil {
+			return err
+		} else if err := resetReviewDeadlines(tx, submissionID); err != nil {
+			return err
+		} else if err := recordEvent(tx, submissionID, EVENT_EDITED, ctx.ID, false,
+			map[string]string{"version": strconv.Itoa(int(submission.Version))}); err != nil {
+			return err
+		} else if err := addFiles(tx, submission); err != nil {
+			return err
+		}
+		// moves the review discussion onto the new version's files
+		return reanchorComments(tx, submission)
+	}); err != nil {
+		return 0, err
+	}
+	if err := storeZip(r.ZipBase64Value, submissionID); err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+// ------
+// Helper Functions
+// ------
+
+// Moves a submission to a new status if its current status allows it,
+// keeping the approved field in sync for clients which only read it, and
+// recording the change in the submission's history.
+// Decisions go through decideSubmission, which checks the acceptance policy.
+//
+// Params:
+// 	tx (*gorm.DB) : the transaction to update the submission in
+// 	submission (*Submission) : the submission, with status and ID set
+// 	status (string) : the new status
+// 	actorID (string) : the user changing the status
+// Returns:
+// 	(error) : an *InvalidStatusTransitionError if the transition is not allowed
+func setSubmissionStatus(tx *gorm.DB, submission *Submission, status string, actorID string) error {
+	if !canTransition(submission.Status, status) {
+		return &InvalidStatusTransitionError{SubmissionID: submission.ID, From: submission.Status, To: status}
+	}
+	return writeSubmissionStatus(tx, submission, status, actorID)
+}
+
+// writes a submission's new status without checking the transition, which
+// only successful appeals do to reopen rejected submissions
+func writeSubmissionStatus(tx *gorm.DB, submission *Submission, status string, actorID string) error {
+	details := map[string]string{"from": submission.Status, "to": status}
+	submission.Status = status
+	submission.Approved = statusApproval(status)
+	if err := tx.Model(&Submission{}).Where("id = ?", submission.ID).
+		Updates(map[string]interface{}{"status": submission.Status, "approved": submission.Approved}).Error; err != nil {
+		return err
+	}
+	return recordEvent(tx, submission.ID, EVENT_STATUS_CHANGED, actorID, false, details)
+}
+
+// checks whether a submission can go from one status to another
+func canTransition(from string, to string) bool {
+	for _, status := range statusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// checks whether a status is final (i.e. the submission cannot be reviewed anymore)
+func isFinalStatus(status string) bool {
+	_, ok := statusTransitions[status]
+	return !ok
+}
+
+// gets the approved value matching a status, nil while no decision is made
+func statusApproval(status string) *bool {
+	switch status {
+	case STATUS_ACCEPTED:
+		approved := true
+		return &approved
+	case STATUS_REJECTED:
+		approved := false
+		return &approved
+	}
+	return nil
+}
+
+// Sets the initial status of a new submission: drafts stay drafts, and
+// submissions with reviewers go straight into review. Submissions only
+// carrying an approval (i.e. imported ones) get the matching final status.
+func initSubmissionStatus(submission *Submission) {
+	submission.Version = 1
+	switch {
+	case submission.Status != "":
+	case submission.Approved != nil && *submission.Approved:
+		submission.Status = STATUS_ACCEPTED
+	case submission.Approved != nil:
+		submission.Status = STATUS_REJECTED
+	case len(submission.Reviewers) > 0:
+		submission.Status = STATUS_UNDER_REVIEW
+	default:
+		submission.Status = STATUS_SUBMITTED
+	}
+	submission.Approved = statusApproval(submission.Status)
+}
+
+// Checks that every reviewer of a submission has reviewed its current version
+// and approves it (i.e. the unanimous acceptance policy).
+func checkReviewsApprove(submission *Submission) error {
+	if len(submission.Reviewers) == 0 {
+		return &MissingReviewsError{SubmissionID: submission.ID}
+	}
+	// maps reviewer ID to review approval status
+	reviews := make(map[string]bool)
+	for _, review := range currentRoundReviews(submission) {
+		reviews[review.ReviewerID] = review.Approved
+	}
+	for _, reviewer := range submission.Reviewers {
+		if approved, ok := reviews[reviewer.ID]; !ok {
+			return &MissingReviewsError{SubmissionID: submission.ID}
+		} else if !approved {
+			return &MissingApprovalError{SubmissionID: submission.ID}
+		}
+	}
+	return nil
+}
+
+// gets the reviews of a submission's current version which have not been
+// withdrawn or archived. Reviews predating versions were made on the first version
+func currentRoundReviews(submission *Submission) []*Review {
+	reviews := []*Review{}
+	if submission.MetaData == nil {
+		return reviews
+	}
+	for _, review := range submission.MetaData.Reviews {
+		if review.WithdrawnAt != nil || review.ArchivedAt != nil {
+			continue
+		}
+		if round := review.Round; round == submission.Version || (round == 0 && submission.Version <= 1) {
+			reviews = append(reviews, review)
+		}
+	}
+	return reviews
+}
+
+// Gives a status to submissions stored before statuses existed, based upon
+// whether they were approved. Only runs once, when the status column is
+// added, so that statuses set by the workflow afterwards are never rewritten.
+func migrateSubmissionStatus(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasTable(&Submission{}) || migrator.HasColumn(&Submission{}, "Status") {
+		return nil
+	} else if err := migrator.AddColumn(&Submission{}, "Status"); err != nil {
+		return err
+	}
+	if err := db.Model(&Submission{}).Where("(status IS NULL OR status = ?) AND approved = ?", STATUS_SUBMITTED, true).
+		Update("status", STATUS_ACCEPTED).Error; err != nil {
+		return err
+	}
+	if err := db.Model(&Submission{}).Where("(status IS NULL OR status = ?) AND approved = ?", STATUS_SUBMITTED, false).
+		Update("status", STATUS_REJECTED).Error; err != nil {
+		return err
+	}
+	return db.Model(&Submission{}).Where("(status IS NULL OR status = ?) AND approved IS NULL AND id IN (?)", STATUS_SUBMITTED,
+		db.Table("reviewers_submission").Select("submission_id")).Update("status", STATUS_UNDER_REVIEW).Error
+}
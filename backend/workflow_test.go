@@ -0,0 +1,110 @@
+// =====================================
+// workflow_test.go
+// Created: October 19, 2026
+//
+// test file for workflow.go
+// =====================================
+
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ------------
+// Helper Function Tests
+// ------------
+
+// tests that only the transitions of the workflow are allowed
+func TestCanTransition(t *testing.T) {
+	allowed := [][2]string{
+		{STATUS_DRAFT, STATUS_SUBMITTED},
+		{STATUS_SUBMITTED, STATUS_UNDER_REVIEW},
+		{STATUS_UNDER_REVIEW, STATUS_REVISIONS_REQUESTED},
+		{STATUS_REVISIONS_REQUESTED, STATUS_RESUBMITTED},
+		{STATUS_RESUBMITTED, STATUS_UNDER_REVIEW},
+		{STATUS_UNDER_REVIEW, STATUS_ACCEPTED},
+		{STATUS_SUBMITTED, STATUS_REJECTED},
+		{STATUS_UNDER_REVIEW, STATUS_WITHDRAWN},
+	}
+	for _, transition := range allowed {
+		assert.Truef(t, canTransition(transition[0], transition[1]), "%s -> %s not allowed", transition[0], transition[1])
+	}
+	disallowed := [][2]string{
+		{STATUS_DRAFT, STATUS_ACCEPTED},
+		{STATUS_SUBMITTED, STATUS_ACCEPTED},
+		{STATUS_REVISIONS_REQUESTED, STATUS_ACCEPTED},
+		{STATUS_ACCEPTED, STATUS_REJECTED},
+		{STATUS_REJECTED, STATUS_UNDER_REVIEW},
+		{STATUS_WITHDRAWN, STATUS_SUBMITTED},
+		{STATUS_UNDER_REVIEW, STATUS_UNDER_REVIEW},
+	}
+	for _, transition := range disallowed {
+		assert.Falsef(t, canTransition(transition[0], transition[1]), "%s -> %s allowed", transition[0], transition[1])
+	}
+	for _, status := range []string{STATUS_ACCEPTED, STATUS_REJECTED, STATUS_WITHDRAWN} {
+		assert.Truef(t, isFinalStatus(status), "%s not final", status)
+	}
+	assert.False(t, isFinalStatus(STATUS_REVISIONS_REQUESTED), "revisions requested is final")
+}
+
+// tests the status given to new submissions
+func TestInitSubmissionStatus(t *testing.T) {
+	approved, rejected := true, false
+	testCases := []struct {
+		name       string
+		submission Submission
+		status     string
+		approved   *bool
+	}{
+		{"new", Submission{}, STATUS_SUBMITTED, nil},
+		{"draft", Submission{Status: STATUS_DRAFT}, STATUS_DRAFT, nil},
+		{"with reviewers", Submission{Reviewers: []GlobalUser{{ID: "r"}}}, STATUS_UNDER_REVIEW, nil},
+		{"approved", Submission{Approved: &approved}, STATUS_ACCEPTED, &approved},
+		{"rejected", Submission{Approved: &rejected}, STATUS_REJECTED, &rejected},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			submission := testCase.submission
+			initSubmissionStatus(&submission)
+			switch {
+			case !assert.Equal(t, testCase.status, submission.Status, "wrong initial status"),
+				!assert.Equal(t, testCase.approved, submission.Approved, "approval does not match status"),
+				!assert.Equal(t, uint(1), submission.Version, "wrong initial version"):
+				return
+			}
+		})
+	}
+}
+
+// tests that acceptance only considers reviews of the current version
+func TestCheckReviewsApprove(t *testing.T) {
+	submission := &Submission{
+		Version:   2,
+		Reviewers: []GlobalUser{{ID: "a"}, {ID: "b"}},
+		MetaData: &SubmissionData{Reviews: []*Review{
+			{ReviewerID: "a", Approved: false, Round: 1},
+			{ReviewerID: "b", Approved: true, Round: 1},
+			{ReviewerID: "a", Approved: true, Round: 2},
+		}},
+	}
+	assert.Len(t, currentRoundReviews(submission), 1, "wrong number of current reviews")
+	assert.IsType(t, &MissingReviewsError{}, checkReviewsApprove(submission), "missing review not detected")
+
+	submission.MetaData.Reviews = append(submission.MetaData.Reviews, &Review{ReviewerID: "b", Approved: false, Round: 2})
+	assert.IsType(t, &MissingApprovalError{}, checkReviewsApprove(submission), "disapproval not detected")
+
+	submission.MetaData.Reviews[3].Approved = true
+	assert.NoError(t, checkReviewsApprove(submission), "approved version not accepted")
+
+	// reviews made before versions existed belong to the first version
+	legacy := &Submission{
+		Version:   1,
+		Reviewers: []GlobalUser{{ID: "a"}},
+		MetaData:  &SubmissionData{Reviews: []*Review{{ReviewerID: "a", Approved: true}}},
+	}
+	assert.NoError(t, checkReviewsApprove(legacy), "legacy review not counted")
+	assert.IsType(t, &MissingReviewsError{}, checkReviewsApprove(&Submission{Version: 1}), "submission without reviewers accepted")
+}
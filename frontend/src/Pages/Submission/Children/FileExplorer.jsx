@@ -26,8 +26,8 @@ export default ({ id, files, onClick }) => {
 		if (array !== undefined) {
 			let struct = []
 			array.map((file) => {
-				if (file.path.slice(-1) !== "/") {
-					// Check if given file is not a directory.
+				if (file.path.slice(-1) !== "/" && !file.attachment) {
+					// Check if given file is not a directory or an attachment.
 					struct = [
 						...struct,
 						{